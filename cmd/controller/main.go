@@ -46,9 +46,11 @@ var (
 	kubeconfigWriterImage    = flag.String("kubeconfig-writer-image", "", "The container image containing our kubeconfig writer binary.")
 	shellImage               = flag.String("shell-image", "", "The container image containing a shell")
 	gsutilImage              = flag.String("gsutil-image", "", "The container image containing gsutil")
+	awsCLIImage              = flag.String("aws-cli-image", "", "The container image containing the AWS CLI")
 	buildGCSFetcherImage     = flag.String("build-gcs-fetcher-image", "", "The container image containing our GCS fetcher binary.")
 	prImage                  = flag.String("pr-image", "", "The container image containing our PR binary.")
 	imageDigestExporterImage = flag.String("imagedigest-exporter-image", "", "The container image containing our image digest exporter binary.")
+	workspaceArtifactImage   = flag.String("workspace-artifact-image", "", "The container image containing our workspace artifact binary.")
 	namespace                = flag.String("namespace", corev1.NamespaceAll, "Namespace to restrict informer to. Optional, defaults to all namespaces.")
 	versionGiven             = flag.String("version", "devel", "Version of Tekton running")
 	threadsPerController     = flag.Int("threads-per-controller", controller.DefaultThreadsPerController, "Threads (goroutines) to create per controller")
@@ -68,9 +70,11 @@ func main() {
 		KubeconfigWriterImage:    *kubeconfigWriterImage,
 		ShellImage:               *shellImage,
 		GsutilImage:              *gsutilImage,
+		AwsCLIImage:              *awsCLIImage,
 		BuildGCSFetcherImage:     *buildGCSFetcherImage,
 		PRImage:                  *prImage,
 		ImageDigestExporterImage: *imageDigestExporterImage,
+		WorkspaceArtifactImage:   *workspaceArtifactImage,
 	}
 	if err := images.Validate(); err != nil {
 		log.Fatal(err)
@@ -42,6 +42,13 @@ var (
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
 	waitPollingInterval = time.Second
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
+	retries             = flag.Int("retries", 0, "If specified, number of times to retry the step if it fails")
+	retryDelay          = flag.Duration("retry_delay", time.Duration(0), "If specified, time to wait before each retry of the step")
+	inactivityTimeout   = flag.Duration("inactivity_timeout", time.Duration(0), "If specified, fails the step if it writes no output for this long")
+	progressFile        = flag.String("progress_file", "", "If specified, file the step may write its progress to")
+	progressAnnotation  = flag.String("progress_annotation", "", "If specified, annotation of the Pod to publish the progress of the step to while it's running")
+	podNameFile         = flag.String("pod_name_file", "", "If specified, file containing the name of the Pod, whose annotation the progress is published to")
+	outputTail          = flag.Bool("output_tail", false, "If specified, reports the last lines of output of the step in the termination message if it fails")
 )
 
 func cp(src, dst string) error {
@@ -103,10 +110,21 @@ func main() {
 		TerminationPath: *terminationPath,
 		Args:            flag.Args(),
 		Waiter:          &realWaiter{},
-		Runner:          &realRunner{},
+		Runner:          &realRunner{inactivityTimeout: *inactivityTimeout, outputTail: *outputTail},
 		PostWriter:      &realPostWriter{},
 		Results:         strings.Split(*results, ","),
 		Timeout:         timeout,
+		Retries:         *retries,
+		RetryDelay:      *retryDelay,
+		ProgressFile:    *progressFile,
+	}
+	if *progressFile != "" && *progressAnnotation != "" {
+		if annotator, err := newPodAnnotator(*podNameFile, *progressAnnotation); err != nil {
+			log.Printf("Not publishing the progress of the step while it's running: %v", err)
+		} else {
+			e.ProgressPublisher = annotator
+			e.ProgressInterval = progressPollingInterval
+		}
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+const (
+	// progressPollingInterval is how often the step's progress file is read.
+	progressPollingInterval = time.Second
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// podAnnotator publishes the progress of the step in an annotation of its Pod,
+// using the credentials of the Pod's ServiceAccount, so that the controller is
+// notified of it while the step is running.
+type podAnnotator struct {
+	client     *http.Client
+	url        string
+	token      string
+	annotation string
+}
+
+// newPodAnnotator returns a podAnnotator setting the annotation of the Pod
+// whose name is written in podNameFile, with the in-cluster configuration.
+func newPodAnnotator(podNameFile, annotation string) (*podAnnotator, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Pod of a Kubernetes cluster")
+	}
+	podName, err := ioutil.ReadFile(podNameFile)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, err
+	}
+	token, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, err
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid CA certificate in %s", serviceAccountDir)
+	}
+	return &podAnnotator{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+		url: fmt.Sprintf("https://%s/api/v1/namespaces/%s/pods/%s", net.JoinHostPort(host, port),
+			bytes.TrimSpace(namespace), bytes.TrimSpace(podName)),
+		token:      string(bytes.TrimSpace(token)),
+		annotation: annotation,
+	}, nil
+}
+
+// Publish implements entrypoint.ProgressPublisher.
+func (a *podAnnotator) Publish(progress string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{a.annotation: progress},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPatch, a.url, bytes.NewReader(patch))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound:
+		// The Pod can't be updated, e.g. because its ServiceAccount
+		// isn't allowed to patch it, so don't try again.
+		return fmt.Errorf("%w: updating the Pod returned status %d", entrypoint.ErrProgressRefused, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("updating the Pod returned status %d", resp.StatusCode)
+	}
+	return nil
+}
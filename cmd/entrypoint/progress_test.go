@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestPodAnnotatorPublish(t *testing.T) {
+	var gotPatch map[string]interface{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/namespaces/ns/pods/pod" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotPatch); err != nil {
+			t.Errorf("error decoding the patch: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	a := &podAnnotator{
+		client:     server.Client(),
+		url:        server.URL + "/api/v1/namespaces/ns/pods/pod",
+		token:      "token",
+		annotation: "tekton.dev/step-progress-0",
+	}
+	if err := a.Publish("42%"); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{"tekton.dev/step-progress-0": "42%"},
+		},
+	}
+	if d := cmp.Diff(want, gotPatch); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+
+	status = http.StatusForbidden
+	if err := a.Publish("43%"); !errors.Is(err, entrypoint.ErrProgressRefused) {
+		t.Errorf("expected publishing to be refused when the Pod can't be patched, got %v", err)
+	}
+	status = http.StatusInternalServerError
+	if err := a.Publish("44%"); err == nil || errors.Is(err, entrypoint.ErrProgressRefused) {
+		t.Errorf("expected a transient error when the API server fails, got %v", err)
+	}
+}
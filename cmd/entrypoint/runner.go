@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
@@ -16,9 +19,59 @@ import (
 // realRunner actually runs commands.
 type realRunner struct {
 	signals chan os.Signal
+	// inactivityTimeout, if non-zero, is how long the command may run
+	// without writing to stdout or stderr before it is killed.
+	inactivityTimeout time.Duration
+	// usage accumulates the resources used by the commands run so far.
+	usage *entrypoint.ResourceUsage
+	// outputTail, if true, keeps the last lines of output of the commands
+	// run, so that they can be reported in the termination message.
+	outputTail bool
+	// tail keeps the last lines of output of the last command run.
+	tail *tailBuffer
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
+var _ entrypoint.ResourceUsageReporter = (*realRunner)(nil)
+var _ entrypoint.OutputTailReporter = (*realRunner)(nil)
+
+// outputDrainTimeout is how long to wait, after the command exits, for the
+// output it wrote to be copied. Processes it left running in the background
+// may hold its stdout or stderr open, and must not keep the Step running.
+const outputDrainTimeout = time.Second
+
+// OutputTail returns the last lines written to stdout and stderr by the last
+// command run.
+func (rr *realRunner) OutputTail() string {
+	if rr.tail == nil {
+		return ""
+	}
+	return rr.tail.String()
+}
+
+// ResourceUsage returns the resources used by all of the commands run so far,
+// summing CPU time and filesystem operations and taking the highest MaxRSS.
+func (rr *realRunner) ResourceUsage() *entrypoint.ResourceUsage {
+	return rr.usage
+}
+
+func (rr *realRunner) addResourceUsage(ps *os.ProcessState) {
+	u := resourceUsage(ps)
+	if u == nil {
+		return
+	}
+	if rr.usage == nil {
+		rr.usage = u
+		return
+	}
+	rr.usage.UserCPUTime += u.UserCPUTime
+	rr.usage.SystemCPUTime += u.SystemCPUTime
+	if u.MaxRSS > rr.usage.MaxRSS {
+		rr.usage.MaxRSS = u.MaxRSS
+	}
+	rr.usage.FilesystemReads += u.FilesystemReads
+	rr.usage.FilesystemWrites += u.FilesystemWrites
+}
 
 func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
@@ -30,28 +83,84 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if rr.signals == nil {
 		rr.signals = make(chan os.Signal, 1)
 	}
-	defer close(rr.signals)
-	signal.Notify(rr.signals)
+	signals := rr.signals
+	defer func() {
+		close(signals)
+		// Reset the channel so that the command can be run again, e.g.
+		// when a Step is retried.
+		rr.signals = nil
+	}()
+	signal.Notify(signals)
 	defer signal.Reset()
 
 	cmd := exec.CommandContext(ctx, name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
 	// dedicated PID group used to forward signals to
 	// main process and all children
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	rr.tail = nil
+	if rr.outputTail {
+		rr.tail = newTailBuffer(outputTailLines)
+		stdout, stderr = io.MultiWriter(stdout, rr.tail), io.MultiWriter(stderr, rr.tail)
+	}
+	var watchdog *inactivityWatchdog
+	if rr.inactivityTimeout > 0 {
+		watchdog = &inactivityWatchdog{timeout: rr.inactivityTimeout}
+		stdout = watchdog.wrap(stdout)
+		stderr = watchdog.wrap(stderr)
+	}
+	// The output is only copied through pipes when it must be inspected, so
+	// that by default the command writes directly to the Step's stdout and
+	// stderr, e.g. to a terminal.
+	var stdoutPipe, stderrPipe *outputPipe
+	if rr.outputTail || watchdog != nil {
+		var err error
+		if stdoutPipe, err = newOutputPipe(stdout); err != nil {
+			return err
+		}
+		if stderrPipe, err = newOutputPipe(stderr); err != nil {
+			stdoutPipe.abort()
+			return err
+		}
+		cmd.Stdout = stdoutPipe.w
+		cmd.Stderr = stderrPipe.w
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
 	// Start defined command
-	if err := cmd.Start(); err != nil {
+	err := cmd.Start()
+	if stdoutPipe != nil {
+		// The command has its own copies of the pipes now, so closing ours means
+		// the copies end once the command and its children are done with them.
+		stdoutPipe.w.Close()
+		stderrPipe.w.Close()
+	}
+	if err != nil {
+		if stdoutPipe != nil {
+			stdoutPipe.abort()
+			stderrPipe.abort()
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return context.DeadlineExceeded
 		}
 		return err
 	}
 
+	if watchdog != nil {
+		// Kill the whole process group so that no child is left holding
+		// stdout or stderr open, which would keep cmd.Wait from returning.
+		watchdog.start(func() {
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		})
+		defer watchdog.stop()
+	}
+
 	// Goroutine for signals forwarding
 	go func() {
-		for s := range rr.signals {
+		for s := range signals {
 			// Forward signal to main process and all children
 			if s != syscall.SIGCHLD {
 				_ = syscall.Kill(-cmd.Process.Pid, s.(syscall.Signal))
@@ -60,12 +169,119 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	}()
 
 	// Wait for command to exit
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	if stdoutPipe != nil {
+		stdoutPipe.drain(outputDrainTimeout)
+		stderrPipe.drain(outputDrainTimeout)
+	}
+	if cmd.ProcessState != nil {
+		rr.addResourceUsage(cmd.ProcessState)
+	}
+	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return context.DeadlineExceeded
 		}
+		if watchdog != nil && watchdog.fired() {
+			return entrypoint.ErrInactivityTimeout
+		}
 		return err
 	}
 
 	return nil
 }
+
+// outputPipe copies what a command writes to it to another writer. Unlike
+// the pipes exec creates for writers that aren't files, it doesn't keep
+// cmd.Wait from returning while background processes hold it open.
+type outputPipe struct {
+	r, w   *os.File
+	copied chan struct{}
+}
+
+func newOutputPipe(dst io.Writer) (*outputPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &outputPipe{r: r, w: w, copied: make(chan struct{})}
+	go func() {
+		defer close(p.copied)
+		defer r.Close()
+		_, _ = io.Copy(dst, r)
+	}()
+	return p, nil
+}
+
+// drain waits up to timeout for everything written to the pipe to be copied.
+func (p *outputPipe) drain(timeout time.Duration) {
+	select {
+	case <-p.copied:
+	case <-time.After(timeout):
+	}
+}
+
+// abort closes the pipe without waiting for it to be drained.
+func (p *outputPipe) abort() {
+	p.w.Close()
+	p.r.Close()
+}
+
+// inactivityWatchdog calls a function if nothing is written through the
+// writers it wraps for longer than its timeout.
+type inactivityWatchdog struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	expired bool
+}
+
+// wrap returns a writer that writes to w and resets the watchdog on every
+// write.
+func (wd *inactivityWatchdog) wrap(w io.Writer) io.Writer {
+	return watchdogWriter{w: w, wd: wd}
+}
+
+// start starts the watchdog, which calls onExpire if it expires.
+func (wd *inactivityWatchdog) start(onExpire func()) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.timer = time.AfterFunc(wd.timeout, func() {
+		wd.mu.Lock()
+		wd.expired = true
+		wd.mu.Unlock()
+		onExpire()
+	})
+}
+
+func (wd *inactivityWatchdog) reset() {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	if wd.timer != nil && !wd.expired {
+		wd.timer.Reset(wd.timeout)
+	}
+}
+
+func (wd *inactivityWatchdog) stop() {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	if wd.timer != nil {
+		wd.timer.Stop()
+	}
+}
+
+func (wd *inactivityWatchdog) fired() bool {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	return wd.expired
+}
+
+type watchdogWriter struct {
+	w  io.Writer
+	wd *inactivityWatchdog
+}
+
+func (ww watchdogWriter) Write(p []byte) (int, error) {
+	ww.wd.reset()
+	return ww.w.Write(p)
+}
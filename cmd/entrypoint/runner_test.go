@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"os"
+	"runtime"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -37,3 +41,87 @@ func TestRealRunnerTimeout(t *testing.T) {
 		t.Fatalf("step didn't timeout")
 	}
 }
+
+// TestRealRunnerInactivityTimeout tests whether cmd is killed if it doesn't write any output for longer than the inactivity timeout.
+func TestRealRunnerInactivityTimeout(t *testing.T) {
+	rr := realRunner{inactivityTimeout: 50 * time.Millisecond}
+	if err := rr.Run(context.Background(), "sh", "-c", "echo started; sleep 10"); err != entrypoint.ErrInactivityTimeout {
+		t.Fatalf("expected step to fail with an inactivity timeout, got: %v", err)
+	}
+}
+
+// TestRealRunnerInactivityTimeoutWithOutput tests that cmd isn't killed as long as it keeps writing output.
+func TestRealRunnerInactivityTimeoutWithOutput(t *testing.T) {
+	rr := realRunner{inactivityTimeout: 200 * time.Millisecond}
+	if err := rr.Run(context.Background(), "sh", "-c", "for i in 1 2 3 4 5; do echo $i; sleep 0.1; done"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+}
+
+// TestRealRunnerResourceUsage tests that the resources used by each command run are accumulated.
+func TestRealRunnerResourceUsage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("resource usage is only reported on linux")
+	}
+	rr := realRunner{}
+	if err := rr.Run(context.Background(), "sh", "-c", "exit 0"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	first := *rr.ResourceUsage()
+	if first.MaxRSS <= 0 {
+		t.Errorf("expected MaxRSS to be reported, got %d", first.MaxRSS)
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", "exit 1"); err == nil {
+		t.Fatal("expected command to fail")
+	}
+	second := *rr.ResourceUsage()
+	if second.UserCPUTime+second.SystemCPUTime < first.UserCPUTime+first.SystemCPUTime {
+		t.Errorf("expected CPU time to accumulate, got %v after %v", second, first)
+	}
+	if second.MaxRSS < first.MaxRSS {
+		t.Errorf("expected MaxRSS to be the highest seen, got %d after %d", second.MaxRSS, first.MaxRSS)
+	}
+}
+
+// TestRealRunnerOutputTail tests that the last lines of stdout and stderr are kept when asked to.
+func TestRealRunnerOutputTail(t *testing.T) {
+	rr := realRunner{}
+	if err := rr.Run(context.Background(), "sh", "-c", "echo out"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if got := rr.OutputTail(); got != "" {
+		t.Errorf("expected no output tail by default, got %q", got)
+	}
+
+	rr = realRunner{outputTail: true}
+	if err := rr.Run(context.Background(), "sh", "-c", "for i in $(seq 1 30); do echo line $i; done"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if got := rr.OutputTail(); !strings.HasPrefix(got, "line 11\n") || !strings.HasSuffix(got, "line 30\n") {
+		t.Errorf("unexpected output tail %q", got)
+	}
+
+	// stdout and stderr are copied independently, so only check that both are kept.
+	if err := rr.Run(context.Background(), "sh", "-c", "echo out; echo err >&2; exit 1"); err == nil {
+		t.Fatal("expected command to fail")
+	}
+	if got := rr.OutputTail(); !strings.Contains(got, "out\n") || !strings.Contains(got, "err\n") {
+		t.Errorf("expected output tail to contain both stdout and stderr, got %q", got)
+	}
+}
+
+// TestRealRunnerBackgroundProcessHoldsOutput tests that the runner doesn't wait
+// for processes left in the background that still hold the command's output open.
+func TestRealRunnerBackgroundProcessHoldsOutput(t *testing.T) {
+	rr := realRunner{outputTail: true}
+	start := time.Now()
+	if err := rr.Run(context.Background(), "sh", "-c", "sleep 10 & echo done"); err != nil {
+		t.Fatalf("unexpected error received: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the runner to return once the command exited, took %v", elapsed)
+	}
+	if got := rr.OutputTail(); got != "done\n" {
+		t.Errorf("expected output tail %q, got %q", "done\n", got)
+	}
+}
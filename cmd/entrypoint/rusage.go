@@ -0,0 +1,15 @@
+// +build !linux
+
+package main
+
+import (
+	"os"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// Reporting resource usage is currently only implemented on Linux, where the
+// units of the reported values are known.
+func resourceUsage(ps *os.ProcessState) *entrypoint.ResourceUsage {
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// resourceUsage returns the resources used by the process described by ps.
+func resourceUsage(ps *os.ProcessState) *entrypoint.ResourceUsage {
+	ru, ok := ps.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return nil
+	}
+	return &entrypoint.ResourceUsage{
+		UserCPUTime:   ps.UserTime(),
+		SystemCPUTime: ps.SystemTime(),
+		// On Linux, ru_maxrss is reported in kilobytes.
+		MaxRSS:           int64(ru.Maxrss) * 1024,
+		FilesystemReads:  int64(ru.Inblock),
+		FilesystemWrites: int64(ru.Oublock),
+	}
+}
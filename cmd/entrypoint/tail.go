@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// outputTailLines is how many of the last lines written by a command
+	// are kept to report if the command fails.
+	outputTailLines = 20
+	// outputTailBytes bounds the size of the reported output tail, so that
+	// it fits in the termination message alongside the Step's results.
+	outputTailBytes = 1024
+)
+
+// tailBuffer is a ring buffer of the last lines written to it. It is safe to
+// write to from multiple goroutines, e.g. when it receives both stdout and
+// stderr.
+type tailBuffer struct {
+	mu sync.Mutex
+	// lines holds the last complete lines written, with lines[start] the
+	// oldest once the buffer has wrapped around.
+	lines [][]byte
+	start int
+	// partial is the current line, which hasn't been terminated yet.
+	partial []byte
+}
+
+func newTailBuffer(n int) *tailBuffer {
+	return &tailBuffer{lines: make([][]byte, 0, n)}
+}
+
+func (tb *tailBuffer) Write(p []byte) (int, error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			tb.partial = truncateFront(append(tb.partial, p...), outputTailBytes)
+			break
+		}
+		tb.addLine(truncateFront(append(tb.partial, p[:i]...), outputTailBytes))
+		tb.partial = nil
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (tb *tailBuffer) addLine(line []byte) {
+	if len(tb.lines) < cap(tb.lines) {
+		tb.lines = append(tb.lines, line)
+		return
+	}
+	if len(tb.lines) == 0 {
+		return
+	}
+	tb.lines[tb.start] = line
+	tb.start = (tb.start + 1) % len(tb.lines)
+}
+
+// String returns the last lines written, at most outputTailBytes long.
+func (tb *tailBuffer) String() string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	var b bytes.Buffer
+	for i := range tb.lines {
+		b.Write(tb.lines[(tb.start+i)%len(tb.lines)])
+		b.WriteByte('\n')
+	}
+	b.Write(tb.partial)
+	return string(truncateFront(b.Bytes(), outputTailBytes))
+}
+
+// truncateFront drops bytes from the front of b so that it is at most max
+// bytes long, without splitting a UTF-8 encoded rune.
+func truncateFront(b []byte, max int) []byte {
+	if len(b) <= max {
+		return b
+	}
+	b = b[len(b)-max:]
+	for len(b) > 0 && !utf8.RuneStart(b[0]) {
+		b = b[1:]
+	}
+	// Copy so that the rest of the original array can be garbage collected.
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTailBuffer(t *testing.T) {
+	for _, c := range []struct {
+		desc   string
+		writes []string
+		want   string
+	}{{
+		desc: "nothing written",
+		want: "",
+	}, {
+		desc:   "fewer lines than the buffer holds",
+		writes: []string{"a\nb\n", "c"},
+		want:   "a\nb\nc",
+	}, {
+		desc:   "line split across writes",
+		writes: []string{"hel", "lo\nwor", "ld\n"},
+		want:   "hello\nworld\n",
+	}, {
+		desc:   "only the last lines are kept",
+		writes: []string{"1\n2\n3\n", "4\n5\n"},
+		want:   "3\n4\n5\n",
+	}, {
+		desc:   "long output is truncated from the front",
+		writes: []string{strings.Repeat("x", outputTailBytes) + "yz"},
+		want:   strings.Repeat("x", outputTailBytes-2) + "yz",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			tb := newTailBuffer(3)
+			for _, w := range c.writes {
+				if _, err := tb.Write([]byte(w)); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if got := tb.String(); got != c.want {
+				t.Errorf("String() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
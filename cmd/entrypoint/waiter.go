@@ -2,14 +2,20 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
-// realWaiter actually waits for files, by polling.
-type realWaiter struct{}
+// realWaiter actually waits for files, by watching the directory containing
+// the file for changes and, as a fallback, polling.
+type realWaiter struct {
+	// pollOnly disables watching for changes so that files are only polled.
+	pollOnly bool
+}
 
 var _ entrypoint.Waiter = (*realWaiter)(nil)
 
@@ -17,16 +23,33 @@ var _ entrypoint.Waiter = (*realWaiter)(nil)
 // the expectContent argument is true, the file has non-zero size or b) there
 // is an error polling the file.
 //
+// The file is checked whenever its directory changes and at least every
+// waitPollingInterval, in case watching isn't supported or misses a change.
+//
 // If the passed-in file is an empty string then this function returns
 // immediately.
 //
 // If a file of the same name with a ".err" extension exists then this Wait
 // will end with a skipError.
-func (*realWaiter) Wait(file string, expectContent bool) error {
+func (rw *realWaiter) Wait(file string, expectContent bool) error {
 	if file == "" {
 		return nil
 	}
-	for ; ; time.Sleep(waitPollingInterval) {
+
+	var changes <-chan struct{}
+	if !rw.pollOnly {
+		c, stop, err := watchDir(filepath.Dir(file))
+		if err != nil {
+			log.Printf("Falling back to polling for %q: %v", file, err)
+		} else {
+			changes = c
+			defer stop()
+		}
+	}
+
+	ticker := time.NewTicker(waitPollingInterval)
+	defer ticker.Stop()
+	for {
 		if info, err := os.Stat(file); err == nil {
 			if !expectContent || info.Size() > 0 {
 				return nil
@@ -37,6 +60,10 @@ func (*realWaiter) Wait(file string, expectContent bool) error {
 		if _, err := os.Stat(file + ".err"); err == nil {
 			return skipError("error file present, bail and skip the step")
 		}
+		select {
+		case <-changes:
+		case <-ticker.C:
+		}
 	}
 }
 
@@ -19,6 +19,8 @@ package main
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -120,3 +122,71 @@ func TestRealWaiterWaitWithContent(t *testing.T) {
 		t.Errorf("expected Wait() to have detected a non-zero file size by now")
 	}
 }
+
+func TestRealWaiterWaitFileCreatedLater(t *testing.T) {
+	dir, err := ioutil.TempDir("", "real_waiter_test_dir")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "0")
+	rw := realWaiter{}
+	doneCh := make(chan struct{})
+	go func() {
+		if err := rw.Wait(file, false); err != nil {
+			t.Errorf("error waiting on file %q: %v", file, err)
+		}
+		close(doneCh)
+	}()
+	if err := ioutil.WriteFile(file, nil, 0700); err != nil {
+		t.Errorf("error creating file: %v", err)
+	}
+	select {
+	case <-doneCh:
+		// Success
+	case <-time.After(2 * waitPollingInterval):
+		t.Errorf("expected Wait() to have detected the file's creation by now")
+	}
+}
+
+// BenchmarkRealWaiter measures how long it takes for Wait() to return after
+// the file it is waiting for is created, as happens between two steps.
+func BenchmarkRealWaiter(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		rw   realWaiter
+	}{{
+		name: "watch",
+		rw:   realWaiter{},
+	}, {
+		name: "poll",
+		rw:   realWaiter{pollOnly: true},
+	}} {
+		b.Run(bc.name, func(b *testing.B) {
+			dir, err := ioutil.TempDir("", "real_waiter_bench_dir")
+			if err != nil {
+				b.Fatalf("error creating temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			var total time.Duration
+			for i := 0; i < b.N; i++ {
+				file := filepath.Join(dir, strconv.Itoa(i))
+				doneCh := make(chan time.Time)
+				go func() {
+					if err := bc.rw.Wait(file, false); err != nil {
+						b.Errorf("error waiting on file %q: %v", file, err)
+					}
+					doneCh <- time.Now()
+				}()
+				// Give Wait() a chance to start watching before the file is created.
+				time.Sleep(10 * time.Millisecond)
+				created := time.Now()
+				if err := ioutil.WriteFile(file, nil, 0700); err != nil {
+					b.Fatalf("error creating file: %v", err)
+				}
+				total += (<-doneCh).Sub(created)
+			}
+			b.ReportMetric(float64(total.Milliseconds())/float64(b.N), "ms/wait")
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// +build !linux
+
+package main
+
+import "errors"
+
+// Watching for changes is currently only implemented on Linux; on other
+// platforms the waiter falls back to polling.
+func watchDir(dir string) (<-chan struct{}, func(), error) {
+	return nil, nil, errors.New("watching for file changes is only implemented on linux")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// watchEvents are the inotify events that may mean a waited for file has
+// appeared or changed. Files written by other steps are created and written
+// in place, while Downward API files are updated by renaming a symlink.
+const watchEvents = syscall.IN_CREATE | syscall.IN_MOVED_TO | syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_ATTRIB
+
+// watchDir uses inotify to watch dir for changes. A value is sent on the
+// returned channel whenever something in dir changes; the returned func stops
+// watching.
+func watchDir(dir string) (<-chan struct{}, func(), error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("inotify_init1", err)
+	}
+	if _, err := syscall.InotifyAddWatch(fd, dir, watchEvents); err != nil {
+		syscall.Close(fd)
+		return nil, nil, os.NewSyscallError("inotify_add_watch", err)
+	}
+
+	// Wrapping the non-blocking fd in an os.File registers it with the
+	// runtime poller, so that closing the file unblocks the pending Read.
+	f := os.NewFile(uintptr(fd), "inotify")
+	changes := make(chan struct{}, 1)
+	go func() {
+		buf := make([]byte, syscall.SizeofInotifyEvent+syscall.NAME_MAX+1)
+		for {
+			if _, err := f.Read(buf); err != nil {
+				return
+			}
+			// We don't care which file changed, the waiter checks
+			// the file it is waiting for on every change.
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changes, func() { f.Close() }, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"flag"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/artifacts/oci"
+	"go.uber.org/zap"
+)
+
+var (
+	mode = flag.String("mode", "", "Whether to push the workspace to, or pull it from, the image reference: push or pull")
+	path = flag.String("path", "", "Path of the directory of the workspace")
+	ref  = flag.String("ref", "", "Image reference of the workspace artifact")
+)
+
+func main() {
+	flag.Parse()
+	prod, _ := zap.NewProduction()
+	logger := prod.Sugar()
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	keychain := remote.WithAuthFromKeychain(authn.DefaultKeychain)
+	switch *mode {
+	case "push":
+		if err := oci.Push(*path, *ref, keychain); err != nil {
+			logger.Fatalf("Error pushing workspace %s: %s", *path, err)
+		}
+		logger.Infof("Pushed workspace %s to %s", *path, *ref)
+	case "pull":
+		found, err := oci.Pull(*path, *ref, keychain)
+		if err != nil {
+			logger.Fatalf("Error pulling workspace %s: %s", *path, err)
+		}
+		if !found {
+			logger.Infof("Skipped %s which does not exist", *ref)
+			return
+		}
+		logger.Infof("Pulled %s into workspace %s", *ref, *path)
+	default:
+		logger.Fatalf("Unknown mode %q, expected push or pull", *mode)
+	}
+}
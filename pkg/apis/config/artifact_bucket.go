@@ -17,7 +17,10 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -41,10 +44,31 @@ const (
 	// DefaultBucketServiceAccountSecretKey defaults to a gcs bucket
 	DefaultBucketServiceFieldName = "GOOGLE_APPLICATION_CREDENTIALS"
 
+	// BotoConfigBucketServiceFieldName is the field name of a boto configuration,
+	// used by gsutil to access S3 buckets.
+	BotoConfigBucketServiceFieldName = "BOTO_CONFIG"
+
 	// bucketServiceAccountFieldName is the name of the configmap entry that specifies
 	// the field name that should be used for the service account.
-	// Valid values: GOOGLE_APPLICATION_CREDENTIALS, BOTO_CONFIG.
+	// Valid values: GOOGLE_APPLICATION_CREDENTIALS, BOTO_CONFIG, AWS_SHARED_CREDENTIALS_FILE.
 	BucketServiceAccountFieldNameKey = "bucket.service.account.field.name"
+
+	// BucketS3EndpointKey is the name of the configmap entry that specifies the endpoint
+	// of the S3-compatible object store, e.g. a MinIO or Ceph RGW service. When empty,
+	// the AWS endpoint of the region is used.
+	BucketS3EndpointKey = "bucket.s3.endpoint"
+
+	// BucketS3RegionKey is the name of the configmap entry that specifies the region
+	// of the S3-compatible bucket.
+	BucketS3RegionKey = "bucket.s3.region"
+
+	// BucketS3ForcePathStyleKey is the name of the configmap entry that specifies whether
+	// path-style requests (endpoint/bucket/key) must be used instead of virtual hosted-style
+	// requests (bucket.endpoint/key). Most on-premise object stores require path-style requests.
+	BucketS3ForcePathStyleKey = "bucket.s3.force.path.style"
+
+	// S3BucketLocationPrefix is the prefix of the locations of S3-compatible buckets.
+	S3BucketLocationPrefix = "s3://"
 )
 
 // ArtifactPVC holds the configurations for the artifacts PVC
@@ -54,6 +78,9 @@ type ArtifactBucket struct {
 	ServiceAccountSecretName string
 	ServiceAccountSecretKey  string
 	ServiceAccountFieldName  string
+	S3Endpoint               string
+	S3Region                 string
+	S3ForcePathStyle         bool
 }
 
 // GetArtifactBucketConfigName returns the name of the configmap containing all
@@ -78,7 +105,18 @@ func (cfg *ArtifactBucket) Equals(other *ArtifactBucket) bool {
 	return other.Location == cfg.Location &&
 		other.ServiceAccountSecretName == cfg.ServiceAccountSecretName &&
 		other.ServiceAccountSecretKey == cfg.ServiceAccountSecretKey &&
-		other.ServiceAccountFieldName == cfg.ServiceAccountFieldName
+		other.ServiceAccountFieldName == cfg.ServiceAccountFieldName &&
+		other.S3Endpoint == cfg.S3Endpoint &&
+		other.S3Region == cfg.S3Region &&
+		other.S3ForcePathStyle == cfg.S3ForcePathStyle
+}
+
+// IsS3 returns true if the bucket is an S3-compatible bucket accessed with the AWS CLI.
+// S3 buckets whose credentials are provided as a boto configuration keep being accessed
+// with gsutil.
+func (cfg *ArtifactBucket) IsS3() bool {
+	return strings.HasPrefix(cfg.Location, S3BucketLocationPrefix) &&
+		cfg.ServiceAccountFieldName != BotoConfigBucketServiceFieldName
 }
 
 // NewArtifactBucketFromMap returns a Config given a map corresponding to a ConfigMap
@@ -103,6 +141,22 @@ func NewArtifactBucketFromMap(cfgMap map[string]string) (*ArtifactBucket, error)
 		tc.ServiceAccountFieldName = serviceAccountFieldName
 	}
 
+	if endpoint, ok := cfgMap[BucketS3EndpointKey]; ok {
+		tc.S3Endpoint = endpoint
+	}
+
+	if region, ok := cfgMap[BucketS3RegionKey]; ok {
+		tc.S3Region = region
+	}
+
+	if forcePathStyle, ok := cfgMap[BucketS3ForcePathStyleKey]; ok {
+		b, err := strconv.ParseBool(forcePathStyle)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %s %q: %w", BucketS3ForcePathStyleKey, forcePathStyle, err)
+		}
+		tc.S3ForcePathStyle = b
+	}
+
 	return &tc, nil
 }
 
@@ -49,6 +49,18 @@ func TestNewArtifactBucketFromConfigMap(t *testing.T) {
 			},
 			fileName: "config-artifact-bucket-all-set",
 		},
+		{
+			expectedConfig: &config.ArtifactBucket{
+				Location:                 "s3://test-bucket",
+				ServiceAccountSecretName: "minio-credentials",
+				ServiceAccountSecretKey:  "credentials",
+				ServiceAccountFieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+				S3Endpoint:               "http://minio.minio.svc.cluster.local:9000",
+				S3Region:                 "us-east-1",
+				S3ForcePathStyle:         true,
+			},
+			fileName: "config-artifact-bucket-s3",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -64,6 +76,31 @@ func TestNewArtifactBucketFromEmptyConfigMap(t *testing.T) {
 	verifyConfigFileWithExpectedArtifactBucketConfig(t, ArtifactBucketConfigEmptyName, expectedConfig)
 }
 
+func TestNewArtifactBucketFromInvalidConfigMap(t *testing.T) {
+	cm := test.ConfigMapFromTestFile(t, "config-artifact-bucket-s3-invalid")
+	if _, err := config.NewArtifactBucketFromConfigMap(cm); err == nil {
+		t.Errorf("NewArtifactBucketFromConfigMap(actual) was expected to return an error")
+	}
+}
+
+func TestArtifactBucketIsS3(t *testing.T) {
+	for _, tc := range []struct {
+		location  string
+		fieldName string
+		want      bool
+	}{
+		{location: "s3://my-bucket", fieldName: "AWS_SHARED_CREDENTIALS_FILE", want: true},
+		{location: "s3://my-bucket", fieldName: "BOTO_CONFIG", want: false},
+		{location: "gs://my-bucket", fieldName: "GOOGLE_APPLICATION_CREDENTIALS", want: false},
+		{location: "", want: false},
+	} {
+		cfg := &config.ArtifactBucket{Location: tc.location, ServiceAccountFieldName: tc.fieldName}
+		if got := cfg.IsS3(); got != tc.want {
+			t.Errorf("IsS3() for location %q and field %q = %t, want %t", tc.location, tc.fieldName, got, tc.want)
+		}
+	}
+}
+
 func TestGetArtifactBucketConfigName(t *testing.T) {
 	for _, tc := range []struct {
 		description             string
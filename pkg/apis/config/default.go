@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -39,8 +40,13 @@ const (
 	defaultCloudEventsSinkKey      = "default-cloud-events-sink"
 	DefaultCloudEventSinkValue     = ""
 	defaultTaskRunWorkspaceBinding = "default-task-run-workspace-binding"
+	defaultVolumeClaimRetentionKey = "default-volume-claim-retention-policy"
 )
 
+// validVolumeClaimRetentionPolicies are the values accepted for the
+// default-volume-claim-retention-policy, matching the v1beta1 VolumeClaimRetentionPolicy.
+var validVolumeClaimRetentionPolicies = []string{"retain", "deleteOnCompletion", "deleteOnSuccess"}
+
 // Defaults holds the default configurations
 // +k8s:deepcopy-gen=true
 type Defaults struct {
@@ -50,6 +56,7 @@ type Defaults struct {
 	DefaultPodTemplate             *pod.Template
 	DefaultCloudEventsSink         string
 	DefaultTaskRunWorkspaceBinding string
+	DefaultVolumeClaimRetention    string
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -76,7 +83,8 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultManagedByLabelValue == cfg.DefaultManagedByLabelValue &&
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
-		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding
+		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
+		other.DefaultVolumeClaimRetention == cfg.DefaultVolumeClaimRetention
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -109,6 +117,9 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		if err := yaml.Unmarshal([]byte(defaultPodTemplate), &podTemplate); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal %v", defaultPodTemplate)
 		}
+		if err := podTemplate.Validate(context.Background()); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", defaultPodTemplateKey, err)
+		}
 		tc.DefaultPodTemplate = &podTemplate
 	}
 
@@ -119,6 +130,19 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
+
+	if retention, ok := cfgMap[defaultVolumeClaimRetentionKey]; ok {
+		valid := false
+		for _, p := range validVolumeClaimRetentionPolicies {
+			if retention == p {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid %s %q, must be one of %v", defaultVolumeClaimRetentionKey, retention, validVolumeClaimRetentionPolicies)
+		}
+		tc.DefaultVolumeClaimRetention = retention
+	}
 	return &tc, nil
 }
 
@@ -55,8 +55,25 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
+		{
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:       config.DefaultTimeoutMinutes,
+				DefaultServiceAccount:       config.DefaultServiceAccountValue,
+				DefaultManagedByLabelValue:  config.DefaultManagedByLabelValue,
+				DefaultVolumeClaimRetention: "deleteOnSuccess",
+			},
+			fileName: "config-defaults-volume-claim-retention",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-volume-claim-retention-invalid",
+		},
 		// the github.com/ghodss/yaml package in the vendor directory does not support UnmarshalStrict
 		// update it, switch to UnmarshalStrict in defaults.go, then uncomment these tests
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pod-template-invalid",
+		},
 		// {
 		// 	expectedError: true,
 		// 	fileName:      "config-defaults-timeout-err",
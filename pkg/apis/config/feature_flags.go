@@ -33,6 +33,9 @@ const (
 	requireGitSSHSecretKnownHostsKey        = "require-git-ssh-secret-known-hosts" // nolint: gosec
 	enableTektonOCIBundles                  = "enable-tekton-oci-bundles"
 	enableCustomTasks                       = "enable-custom-tasks"
+	enableStepProgress                      = "enable-step-progress"
+	enableScriptParamEnvVars                = "enable-script-param-env-vars"
+	enableOutputTail                        = "enable-output-tail"
 	DefaultDisableHomeEnvOverwrite          = false
 	DefaultDisableWorkingDirOverwrite       = false
 	DefaultDisableAffinityAssistant         = false
@@ -41,6 +44,9 @@ const (
 	DefaultRequireGitSSHSecretKnownHosts    = false
 	DefaultEnableTektonOciBundles           = false
 	DefaultEnableCustomTasks                = false
+	DefaultEnableStepProgress               = false
+	DefaultEnableScriptParamEnvVars         = false
+	DefaultEnableOutputTail                 = false
 )
 
 // FeatureFlags holds the features configurations
@@ -54,6 +60,9 @@ type FeatureFlags struct {
 	RequireGitSSHSecretKnownHosts    bool
 	EnableTektonOCIBundles           bool
 	EnableCustomTasks                bool
+	EnableStepProgress               bool
+	EnableScriptParamEnvVars         bool
+	EnableOutputTail                 bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -105,6 +114,15 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(enableCustomTasks, DefaultEnableCustomTasks, &tc.EnableCustomTasks); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableStepProgress, DefaultEnableStepProgress, &tc.EnableStepProgress); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableScriptParamEnvVars, DefaultEnableScriptParamEnvVars, &tc.EnableScriptParamEnvVars); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableOutputTail, DefaultEnableOutputTail, &tc.EnableOutputTail); err != nil {
+		return nil, err
+	}
 	return &tc, nil
 }
 
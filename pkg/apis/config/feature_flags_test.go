@@ -48,6 +48,9 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RequireGitSSHSecretKnownHosts:    true,
 				EnableTektonOCIBundles:           true,
 				EnableCustomTasks:                true,
+				EnableStepProgress:               true,
+				EnableScriptParamEnvVars:         true,
+				EnableOutputTail:                 true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
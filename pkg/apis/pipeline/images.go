@@ -36,12 +36,16 @@ type Images struct {
 	ShellImage string
 	// GsutilImage is the container image containing gsutil.
 	GsutilImage string
+	// AwsCLIImage is the container image containing the AWS CLI, used to talk to S3-compatible object stores.
+	AwsCLIImage string
 	// BuildGCSFetcherImage is the container image containing our GCS fetcher binary.
 	BuildGCSFetcherImage string
 	// PRImage is the container image that we use to implement the PR source step.
 	PRImage string
 	// ImageDigestExporterImage is the container image containing our image digest exporter binary.
 	ImageDigestExporterImage string
+	// WorkspaceArtifactImage is the container image containing our workspace artifact binary.
+	WorkspaceArtifactImage string
 
 	// NOTE: Make sure to add any new images to Validate below!
 }
@@ -58,9 +62,11 @@ func (i Images) Validate() error {
 		{i.KubeconfigWriterImage, "kubeconfig-writer"},
 		{i.ShellImage, "shell"},
 		{i.GsutilImage, "gsutil"},
+		{i.AwsCLIImage, "aws-cli"},
 		{i.BuildGCSFetcherImage, "build-gcs-fetcher"},
 		{i.PRImage, "pr"},
 		{i.ImageDigestExporterImage, "imagedigest-exporter"},
+		{i.WorkspaceArtifactImage, "workspace-artifact"},
 	} {
 		if f.v == "" {
 			unset = append(unset, f.name)
@@ -14,9 +14,11 @@ func TestValidate(t *testing.T) {
 		KubeconfigWriterImage:    "set",
 		ShellImage:               "set",
 		GsutilImage:              "set",
+		AwsCLIImage:              "set",
 		BuildGCSFetcherImage:     "set",
 		PRImage:                  "set",
 		ImageDigestExporterImage: "set",
+		WorkspaceArtifactImage:   "set",
 	}
 	if err := valid.Validate(); err != nil {
 		t.Errorf("valid Images returned error: %v", err)
@@ -29,11 +31,13 @@ func TestValidate(t *testing.T) {
 		KubeconfigWriterImage:    "set",
 		ShellImage:               "", // unset!
 		GsutilImage:              "set",
+		AwsCLIImage:              "", // unset!
 		BuildGCSFetcherImage:     "", // unset!
 		PRImage:                  "", // unset!
 		ImageDigestExporterImage: "set",
+		WorkspaceArtifactImage:   "", // unset!
 	}
-	wantErr := "found unset image flags: [build-gcs-fetcher git pr shell]"
+	wantErr := "found unset image flags: [aws-cli build-gcs-fetcher git pr shell workspace-artifact]"
 	if err := invalid.Validate(); err == nil {
 		t.Error("invalid Images expected error, got nil")
 	} else if err.Error() != wantErr {
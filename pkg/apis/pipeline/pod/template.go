@@ -104,6 +104,31 @@ type Template struct {
 	// HostNetwork specifies whether the pod may use the node network namespace
 	// +optional
 	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// DisableHostNetwork turns off the HostNetwork enabled by a less specific
+	// pod template, such as the default pod template.
+	// +optional
+	DisableHostNetwork bool `json:"disableHostNetwork,omitempty"`
+
+	// TopologySpreadConstraints describes how the pods are spread across
+	// the topology domains of the cluster.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// HostAliases is a list of hosts and IPs that will be injected into the
+	// pod's hosts file.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// Env is a list of environment variables to set in all the steps. The
+	// environment variables the steps specify take precedence.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom is a list of sources to populate environment variables in all
+	// the steps. The environment variables the steps specify take precedence.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 }
 
 func (tpl *Template) Equals(other *Template) bool {
@@ -117,3 +142,160 @@ func (tpl *Template) Equals(other *Template) bool {
 
 	return reflect.DeepEqual(tpl, other)
 }
+
+// MergePodTemplateWithDefault merges the template into the default template,
+// field by field, and returns the result. The fields the template specifies
+// replace the default ones, except that node selectors are merged by key,
+// tolerations and envFrom sources are combined, volumes, image pull secrets
+// and env vars are merged by name, and host aliases are merged by IP.
+func MergePodTemplateWithDefault(tpl, defaultTpl *Template) *Template {
+	switch {
+	case defaultTpl == nil:
+		return tpl
+	case tpl == nil:
+		return defaultTpl
+	}
+	merged := defaultTpl.DeepCopy()
+	tpl = tpl.DeepCopy()
+
+	if len(tpl.NodeSelector) > 0 {
+		if merged.NodeSelector == nil {
+			merged.NodeSelector = map[string]string{}
+		}
+		for k, v := range tpl.NodeSelector {
+			merged.NodeSelector[k] = v
+		}
+	}
+	for _, t := range tpl.Tolerations {
+		if !containsToleration(merged.Tolerations, t) {
+			merged.Tolerations = append(merged.Tolerations, t)
+		}
+	}
+	if tpl.Affinity != nil {
+		merged.Affinity = tpl.Affinity
+	}
+	if tpl.SecurityContext != nil {
+		merged.SecurityContext = tpl.SecurityContext
+	}
+	merged.Volumes = mergeVolumes(merged.Volumes, tpl.Volumes)
+	if tpl.RuntimeClassName != nil {
+		merged.RuntimeClassName = tpl.RuntimeClassName
+	}
+	if tpl.AutomountServiceAccountToken != nil {
+		merged.AutomountServiceAccountToken = tpl.AutomountServiceAccountToken
+	}
+	if tpl.DNSPolicy != nil {
+		merged.DNSPolicy = tpl.DNSPolicy
+	}
+	if tpl.DNSConfig != nil {
+		merged.DNSConfig = tpl.DNSConfig
+	}
+	if tpl.EnableServiceLinks != nil {
+		merged.EnableServiceLinks = tpl.EnableServiceLinks
+	}
+	if tpl.PriorityClassName != nil {
+		merged.PriorityClassName = tpl.PriorityClassName
+	}
+	if tpl.SchedulerName != "" {
+		merged.SchedulerName = tpl.SchedulerName
+	}
+	merged.ImagePullSecrets = mergeImagePullSecrets(merged.ImagePullSecrets, tpl.ImagePullSecrets)
+	if tpl.HostNetwork {
+		merged.HostNetwork, merged.DisableHostNetwork = true, false
+	}
+	if tpl.DisableHostNetwork {
+		merged.HostNetwork, merged.DisableHostNetwork = false, true
+	}
+	if len(tpl.TopologySpreadConstraints) > 0 {
+		merged.TopologySpreadConstraints = tpl.TopologySpreadConstraints
+	}
+	merged.HostAliases = mergeHostAliases(merged.HostAliases, tpl.HostAliases)
+	merged.Env = mergeEnv(merged.Env, tpl.Env)
+	for _, e := range tpl.EnvFrom {
+		if !containsEnvFromSource(merged.EnvFrom, e) {
+			merged.EnvFrom = append(merged.EnvFrom, e)
+		}
+	}
+	return merged
+}
+
+func containsToleration(tolerations []corev1.Toleration, t corev1.Toleration) bool {
+	for _, existing := range tolerations {
+		if reflect.DeepEqual(existing, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeVolumes(volumes, overrides []corev1.Volume) []corev1.Volume {
+	for _, o := range overrides {
+		found := false
+		for i := range volumes {
+			if volumes[i].Name == o.Name {
+				volumes[i] = o
+				found = true
+			}
+		}
+		if !found {
+			volumes = append(volumes, o)
+		}
+	}
+	return volumes
+}
+
+func mergeImagePullSecrets(secrets, overrides []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, o := range overrides {
+		found := false
+		for _, s := range secrets {
+			if s.Name == o.Name {
+				found = true
+			}
+		}
+		if !found {
+			secrets = append(secrets, o)
+		}
+	}
+	return secrets
+}
+
+func mergeHostAliases(aliases, overrides []corev1.HostAlias) []corev1.HostAlias {
+	for _, o := range overrides {
+		found := false
+		for i := range aliases {
+			if aliases[i].IP == o.IP {
+				aliases[i] = o
+				found = true
+			}
+		}
+		if !found {
+			aliases = append(aliases, o)
+		}
+	}
+	return aliases
+}
+
+func mergeEnv(env, overrides []corev1.EnvVar) []corev1.EnvVar {
+	for _, o := range overrides {
+		found := false
+		for i := range env {
+			if env[i].Name == o.Name {
+				env[i] = o
+				found = true
+			}
+		}
+		if !found {
+			env = append(env, o)
+		}
+	}
+	return env
+}
+
+func containsEnvFromSource(sources []corev1.EnvFromSource, s corev1.EnvFromSource) bool {
+	for _, existing := range sources {
+		if reflect.DeepEqual(existing, s) {
+			return true
+		}
+	}
+	return false
+}
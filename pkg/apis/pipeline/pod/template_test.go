@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergePodTemplateWithDefault(t *testing.T) {
+	priorityClassName := "high"
+	dnsPolicy := corev1.DNSNone
+	toleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists}
+	for _, tc := range []struct {
+		name       string
+		tpl        *Template
+		defaultTpl *Template
+		want       *Template
+	}{{
+		name: "no templates",
+	}, {
+		name: "no default template",
+		tpl:  &Template{SchedulerName: "scheduler"},
+		want: &Template{SchedulerName: "scheduler"},
+	}, {
+		name:       "no template",
+		defaultTpl: &Template{SchedulerName: "scheduler"},
+		want:       &Template{SchedulerName: "scheduler"},
+	}, {
+		name: "merged field by field",
+		tpl: &Template{
+			NodeSelector:       map[string]string{"disktype": "ssd", "zone": "b"},
+			Tolerations:        []corev1.Toleration{toleration, {Key: "gpu", Operator: corev1.TolerationOpExists}},
+			Volumes:            []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}}}},
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}, {Name: "other-registry"}},
+			DNSPolicy:          &dnsPolicy,
+			SchedulerName:      "scheduler",
+			DisableHostNetwork: true,
+			HostAliases:        []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+			Env:                []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other-proxy"}},
+			EnvFrom:            []corev1.EnvFromSource{{Prefix: "FOO_"}},
+		},
+		defaultTpl: &Template{
+			NodeSelector:      map[string]string{"zone": "a"},
+			Tolerations:       []corev1.Toleration{toleration},
+			SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &[]bool{true}[0]},
+			Volumes:           []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry"}},
+			PriorityClassName: &priorityClassName,
+			SchedulerName:     "default-scheduler",
+			HostNetwork:       true,
+			HostAliases:       []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"old.local"}}, {IP: "10.0.0.2", Hostnames: []string{"cache.local"}}},
+			Env:               []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy"}, {Name: "NO_PROXY", Value: "localhost"}},
+			EnvFrom:           []corev1.EnvFromSource{{Prefix: "FOO_"}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+		want: &Template{
+			NodeSelector:       map[string]string{"disktype": "ssd", "zone": "b"},
+			Tolerations:        []corev1.Toleration{toleration, {Key: "gpu", Operator: corev1.TolerationOpExists}},
+			SecurityContext:    &corev1.PodSecurityContext{RunAsNonRoot: &[]bool{true}[0]},
+			Volumes:            []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}}}},
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}, {Name: "other-registry"}},
+			DNSPolicy:          &dnsPolicy,
+			PriorityClassName:  &priorityClassName,
+			SchedulerName:      "scheduler",
+			DisableHostNetwork: true,
+			HostAliases:        []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}, {IP: "10.0.0.2", Hostnames: []string{"cache.local"}}},
+			Env:                []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other-proxy"}, {Name: "NO_PROXY", Value: "localhost"}},
+			EnvFrom:            []corev1.EnvFromSource{{Prefix: "FOO_"}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MergePodTemplateWithDefault(tc.tpl, tc.defaultTpl)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergePodTemplateWithDefault() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMergePodTemplateWithDefaultDoesNotModifyTemplates(t *testing.T) {
+	tpl := &Template{NodeSelector: map[string]string{"disktype": "ssd"}}
+	defaultTpl := &Template{NodeSelector: map[string]string{"zone": "a"}}
+	MergePodTemplateWithDefault(tpl, defaultTpl)
+	if len(defaultTpl.NodeSelector) != 1 || len(tpl.NodeSelector) != 1 {
+		t.Errorf("MergePodTemplateWithDefault() modified the templates: %v, %v", tpl.NodeSelector, defaultTpl.NodeSelector)
+	}
+}
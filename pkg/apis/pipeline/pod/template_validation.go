@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate validates the fields of the pod template that are passed through
+// to the containers and the pod spec without being validated otherwise.
+func (tpl *Template) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if tpl == nil {
+		return nil
+	}
+	for i, e := range tpl.Env {
+		if msgs := validation.IsEnvVarName(e.Name); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q: %s", e.Name, strings.Join(msgs, "; ")), "name").ViaFieldIndex("env", i))
+		}
+	}
+	for i, e := range tpl.EnvFrom {
+		if (e.ConfigMapRef == nil) == (e.SecretRef == nil) {
+			errs = errs.Also(apis.ErrMissingOneOf("configMapRef", "secretRef").ViaFieldIndex("envFrom", i))
+		}
+	}
+	for i, h := range tpl.HostAliases {
+		if msgs := validation.IsValidIP(h.IP); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(h.IP, "ip").ViaFieldIndex("hostAliases", i))
+		}
+		if len(h.Hostnames) == 0 {
+			errs = errs.Also(apis.ErrMissingField("hostnames").ViaFieldIndex("hostAliases", i))
+		}
+		for j, hostname := range h.Hostnames {
+			if msgs := validation.IsDNS1123Subdomain(hostname); len(msgs) > 0 {
+				errs = errs.Also(apis.ErrInvalidArrayValue(hostname, "hostnames", j).ViaFieldIndex("hostAliases", i))
+			}
+		}
+	}
+	for i, c := range tpl.TopologySpreadConstraints {
+		errs = errs.Also(validateTopologySpreadConstraint(c).ViaFieldIndex("topologySpreadConstraints", i))
+	}
+	return errs
+}
+
+func validateTopologySpreadConstraint(c corev1.TopologySpreadConstraint) (errs *apis.FieldError) {
+	if c.MaxSkew <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be > 0", c.MaxSkew), "maxSkew"))
+	}
+	if c.TopologyKey == "" {
+		errs = errs.Also(apis.ErrMissingField("topologyKey"))
+	}
+	switch c.WhenUnsatisfiable {
+	case corev1.DoNotSchedule, corev1.ScheduleAnyway:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q should be %s or %s", c.WhenUnsatisfiable, corev1.DoNotSchedule, corev1.ScheduleAnyway), "whenUnsatisfiable"))
+	}
+	return errs
+}
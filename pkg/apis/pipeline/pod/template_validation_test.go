@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+func TestTemplate_Validate(t *testing.T) {
+	tpl := &Template{
+		Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+		EnvFrom: []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"}},
+		}},
+		HostAliases: []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}},
+	}
+	if err := tpl.Validate(context.Background()); err != nil {
+		t.Errorf("Template.Validate() = %v", err)
+	}
+	var nilTpl *Template
+	if err := nilTpl.Validate(context.Background()); err != nil {
+		t.Errorf("Template.Validate() = %v", err)
+	}
+}
+
+func TestTemplate_Invalidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tpl     *Template
+		wantErr *apis.FieldError
+	}{{
+		name:    "invalid env var name",
+		tpl:     &Template{Env: []corev1.EnvVar{{Name: "1=2"}}},
+		wantErr: apis.ErrInvalidValue(`"1=2": `+strings.Join(validation.IsEnvVarName("1=2"), "; "), "env[0].name"),
+	}, {
+		name:    "envFrom without a source",
+		tpl:     &Template{EnvFrom: []corev1.EnvFromSource{{Prefix: "FOO_"}}},
+		wantErr: apis.ErrMissingOneOf("envFrom[0].configMapRef", "envFrom[0].secretRef"),
+	}, {
+		name: "invalid host alias",
+		tpl:  &Template{HostAliases: []corev1.HostAlias{{IP: "not-an-ip", Hostnames: []string{"Not_A_Host"}}}},
+		wantErr: apis.ErrInvalidValue("not-an-ip", "hostAliases[0].ip").Also(
+			apis.ErrInvalidArrayValue("Not_A_Host", "hostAliases[0].hostnames", 0)),
+	}, {
+		name:    "host alias without hostnames",
+		tpl:     &Template{HostAliases: []corev1.HostAlias{{IP: "10.0.0.1"}}},
+		wantErr: apis.ErrMissingField("hostAliases[0].hostnames"),
+	}, {
+		name: "invalid topology spread constraint",
+		tpl:  &Template{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{}}},
+		wantErr: apis.ErrInvalidValue("0 should be > 0", "topologySpreadConstraints[0].maxSkew").Also(
+			apis.ErrMissingField("topologySpreadConstraints[0].topologyKey")).Also(
+			apis.ErrInvalidValue(`"" should be DoNotSchedule or ScheduleAnyway`, "topologySpreadConstraints[0].whenUnsatisfiable")),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tpl.Validate(context.Background())
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
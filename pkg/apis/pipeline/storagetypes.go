@@ -22,4 +22,7 @@ const (
 
 	// ArtifactStoragePVCType holds the name of the PipelineResource type for a pvc
 	ArtifactStoragePVCType = "pvc"
+
+	// ArtifactStorageOCIType holds the name of the artifact storage type for an image repository
+	ArtifactStorageOCIType = "oci"
 )
@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -219,12 +220,13 @@ type PipelineTaskRunSpec struct {
 
 // GetTaskRunSpecs returns the task specific spec for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's default.
+// The task specific pod template is merged into the PipelineRun's.
 func (pr *PipelineRun) GetTaskRunSpecs(pipelineTaskName string) (string, *PodTemplate) {
 	serviceAccountName := pr.GetServiceAccountName(pipelineTaskName)
 	taskPodTemplate := pr.Spec.PodTemplate
 	for _, task := range pr.Spec.TaskRunSpecs {
 		if task.PipelineTaskName == pipelineTaskName {
-			taskPodTemplate = task.TaskPodTemplate
+			taskPodTemplate = pod.MergePodTemplateWithDefault(task.TaskPodTemplate, taskPodTemplate)
 			serviceAccountName = task.TaskServiceAccountName
 		}
 	}
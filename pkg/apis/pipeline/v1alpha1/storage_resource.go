@@ -27,4 +27,8 @@ const (
 	// PipelineResourceTypeBuildGCS is the subtype for the BuildGCSResources, which is simialr to the GCSResource but
 	// with additional functionality that was added to be compatible with knative build.
 	PipelineResourceTypeBuildGCS PipelineResourceType = resource.PipelineResourceTypeBuildGCS
+
+	// PipelineResourceTypeS3 is the subtype for the S3Resources, which is backed by an object/prefix in an
+	// S3-compatible object store.
+	PipelineResourceTypeS3 PipelineResourceType = resource.PipelineResourceTypeS3
 )
@@ -84,8 +84,10 @@ func MergeStepsWithStepTemplate(template *v1.Container, steps []Step) ([]Step, e
 			merged.Args = []string{}
 		}
 
-		// Pass through original step Script, for later conversion.
-		steps[i] = Step{Container: *merged, Script: s.Script}
+		// Pass through the rest of the original step, such as its Script
+		// for later conversion.
+		s.Container = *merged
+		steps[i] = s
 	}
 	return steps, nil
 }
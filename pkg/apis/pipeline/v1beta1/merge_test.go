@@ -18,11 +18,13 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMergeStepsWithStepTemplate(t *testing.T) {
@@ -101,6 +103,30 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 				Value: "NEW_VALUE",
 			}},
 		}}},
+	}, {
+		name: "keeps-step-fields",
+		template: &corev1.Container{
+			Command: []string{"/somecmd"},
+		},
+		steps: []Step{{
+			Container:  corev1.Container{Image: "some-image"},
+			Script:     "echo hello",
+			Timeout:    &metav1.Duration{Duration: time.Minute},
+			Retries:    2,
+			Group:      "checks",
+			Workspaces: []WorkspaceUsage{{Name: "source"}},
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Command: []string{"/somecmd"},
+				Image:   "some-image",
+			},
+			Script:     "echo hello",
+			Timeout:    &metav1.Duration{Duration: time.Minute},
+			Retries:    2,
+			Group:      "checks",
+			Workspaces: []WorkspaceUsage{{Name: "source"}},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := MergeStepsWithStepTemplate(tc.template, tc.steps)
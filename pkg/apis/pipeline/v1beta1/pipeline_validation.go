@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -62,6 +63,8 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validatePipelineParameterVariables(ps.Tasks, ps.Params).ViaField("tasks"))
 	errs = errs.Also(validatePipelineParameterVariables(ps.Finally, ps.Params).ViaField("finally"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Tasks))
+	errs = errs.Also(validatePipelineWorkspacesVariables(ps.Tasks, ps.Workspaces).ViaField("tasks"))
+	errs = errs.Also(validatePipelineWorkspacesVariables(ps.Finally, ps.Workspaces).ViaField("finally"))
 	errs = errs.Also(validateExecutionStatusVariables(ps.Tasks, ps.Finally))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspaces(ps.Workspaces, ps.Tasks, ps.Finally))
@@ -291,6 +294,54 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 	return errs.Also(validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipeline", pipelineContextNames))
 }
 
+// validatePipelineWorkspacesVariables validates that the workspace variables, e.g. $(workspaces.<name>.bound), used
+// in the params and when expressions of the pipeline tasks refer to workspaces declared by the pipeline, and to
+// one of their bound or commit attributes.
+func validatePipelineWorkspacesVariables(tasks []PipelineTask, wss []PipelineWorkspaceDeclaration) (errs *apis.FieldError) {
+	workspaceNames := sets.NewString()
+	for _, ws := range wss {
+		workspaceNames.Insert(ws.Name)
+	}
+	for idx, task := range tasks {
+		for _, param := range task.Params {
+			if param.Value.Type == ParamTypeString {
+				errs = errs.Also(validateWorkspaceVariable(param.Value.StringVal, workspaceNames).ViaFieldKey("params", param.Name).ViaIndex(idx))
+				continue
+			}
+			for i, arrayElement := range param.Value.ArrayVal {
+				errs = errs.Also(validateWorkspaceVariable(arrayElement, workspaceNames).ViaFieldIndex("value", i).ViaFieldKey("params", param.Name).ViaIndex(idx))
+			}
+		}
+		for i, we := range task.WhenExpressions {
+			errs = errs.Also(validateWorkspaceVariable(we.Input, workspaceNames).ViaField("input").ViaFieldIndex("when", i).ViaIndex(idx))
+			for _, value := range we.Values {
+				errs = errs.Also(validateWorkspaceVariable(value, workspaceNames).ViaField("values").ViaFieldIndex("when", i).ViaIndex(idx))
+			}
+		}
+	}
+	return errs
+}
+
+// pipelineWorkspaceVariableRegex matches the workspace variables, capturing the name of the workspace and
+// the rest of the variable.
+var pipelineWorkspaceVariableRegex = regexp.MustCompile(`\$\(workspaces\.([^.)]*)(\.[^)]*)?\)`)
+
+// validateWorkspaceVariable validates that the workspace variables in value refer to one of workspaceNames, and
+// to an attribute of the workspace available in a pipeline: bound or commit.
+func validateWorkspaceVariable(value string, workspaceNames sets.String) *apis.FieldError {
+	attributes := sets.NewString(".bound", ".commit")
+	for _, match := range pipelineWorkspaceVariableRegex.FindAllStringSubmatch(value, -1) {
+		if !workspaceNames.Has(match[1]) || !attributes.Has(match[2]) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent variable in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+	}
+	return nil
+}
+
 func validateExecutionStatusVariables(tasks []PipelineTask, finallyTasks []PipelineTask) (errs *apis.FieldError) {
 	// creating a list of pipelineTask names to validate tasks.<name>.status
 	pipelineRunTasksContextNames := sets.String{}
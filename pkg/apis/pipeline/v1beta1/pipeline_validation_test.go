@@ -1550,6 +1550,95 @@ func TestValidatePipelineWorkspaces_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineWorkspacesVariables(t *testing.T) {
+	workspaces := []PipelineWorkspaceDeclaration{{
+		Name: "source",
+	}, {
+		Name:     "lint-config",
+		Optional: true,
+	}}
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "workspace variables of declared workspaces",
+		tasks: []PipelineTask{{
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			Params: []Param{{
+				Name: "has-config", Value: *NewArrayOrString("$(workspaces.lint-config.bound)"),
+			}, {
+				Name: "values", Value: *NewArrayOrString("$(workspaces.source.bound)", "$(workspaces.source.commit)"),
+			}},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(workspaces.lint-config.bound)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+	}, {
+		name: "workspace variable of an undeclared workspace in params",
+		tasks: []PipelineTask{{
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			Params: []Param{{
+				Name: "has-config", Value: *NewArrayOrString("$(workspaces.config.bound)"),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.config.bound)"`,
+			Paths:   []string{"[0].params[has-config]"},
+		},
+	}, {
+		name: "workspace variable of an undeclared workspace in when expressions",
+		tasks: []PipelineTask{{
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "true",
+				Operator: selection.In,
+				Values:   []string{"$(workspaces.config.bound)"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.config.bound)"`,
+			Paths:   []string{"[0].when[0].values"},
+		},
+	}, {
+		name: "workspace variable with an attribute not available in a pipeline",
+		tasks: []PipelineTask{{
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			Params: []Param{{
+				Name: "path", Value: *NewArrayOrString("$(workspaces.source.path)"),
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.source.path)"`,
+			Paths:   []string{"[0].params[path]"},
+		},
+	}, {
+		name: "workspace variable without an attribute",
+		tasks: []PipelineTask{{
+			Name: "lint", TaskRef: &TaskRef{Name: "lint"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(workspaces.lint-config)",
+				Operator: selection.In,
+				Values:   []string{"true"},
+			}},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.lint-config)"`,
+			Paths:   []string{"[0].when[0].input"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineWorkspacesVariables(tt.tasks, workspaces)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("validatePipelineWorkspacesVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWithFinalTasks_Success(t *testing.T) {
 	tests := []struct {
 		name string
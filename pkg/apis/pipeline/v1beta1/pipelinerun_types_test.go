@@ -383,6 +383,59 @@ func TestPipelineRunGetPodSpecSABackcompatibility(t *testing.T) {
 	}
 }
 
+func TestPipelineRunGetTaskRunSpecMergesPodTemplates(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PodTemplate: &pod.Template{
+				SchedulerName: "scheduleTest",
+				NodeSelector:  map[string]string{"zone": "a"},
+			},
+			PipelineRef: &v1beta1.PipelineRef{Name: "prs"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "taskNameOne",
+				TaskPodTemplate:  &pod.Template{NodeSelector: map[string]string{"disktype": "ssd"}},
+			}},
+		},
+	}
+	want := &pod.Template{
+		SchedulerName: "scheduleTest",
+		NodeSelector:  map[string]string{"zone": "a", "disktype": "ssd"},
+	}
+	if d := cmp.Diff(want, pr.GetTaskRunSpec("taskNameOne").TaskPodTemplate); d != "" {
+		t.Errorf("wrong task pod template %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(pr.Spec.PodTemplate, pr.GetTaskRunSpec("unknown").TaskPodTemplate); d != "" {
+		t.Errorf("wrong default pod template %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunGetTaskRunSpecOverrides(t *testing.T) {
+	stepOverrides := []v1beta1.TaskRunStepOverride{{Name: "build", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}}}
+	sidecarOverrides := []v1beta1.TaskRunSidecarOverride{{Name: "proxy", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "prs"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "taskNameOne",
+				StepOverrides:    stepOverrides,
+				SidecarOverrides: sidecarOverrides,
+			}},
+		},
+	}
+	s := pr.GetTaskRunSpec("taskNameOne")
+	if d := cmp.Diff(stepOverrides, s.StepOverrides); d != "" {
+		t.Errorf("wrong step overrides %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(sidecarOverrides, s.SidecarOverrides); d != "" {
+		t.Errorf("wrong sidecar overrides %s", diff.PrintWantGot(d))
+	}
+	if s := pr.GetTaskRunSpec("unknown"); s.StepOverrides != nil || s.SidecarOverrides != nil {
+		t.Errorf("expected no overrides for an unknown task, got %v and %v", s.StepOverrides, s.SidecarOverrides)
+	}
+}
+
 func TestPipelineRunGetPodSpec(t *testing.T) {
 	for _, tt := range []struct {
 		name                 string
@@ -83,6 +83,13 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	errs = errs.Also(ps.PodTemplate.Validate(ctx).ViaField("podTemplate"))
+	for idx, trs := range ps.TaskRunSpecs {
+		errs = errs.Also(trs.TaskPodTemplate.Validate(ctx).ViaField("taskPodTemplate").ViaFieldIndex("taskRunSpecs", idx))
+		errs = errs.Also(validateStepOverrides(trs.StepOverrides).ViaField("stepOverrides").ViaFieldIndex("taskRunSpecs", idx))
+		errs = errs.Also(validateSidecarOverrides(trs.SidecarOverrides).ViaField("sidecarOverrides").ViaFieldIndex("taskRunSpecs", idx))
+	}
+
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
 		for idx, ws := range ps.Workspaces {
@@ -289,13 +289,29 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		wantErr: &apis.FieldError{
 			Message: "expected exactly one, got neither",
 			Paths: []string{
+				"workspaces[0].cache",
 				"workspaces[0].configmap",
+				"workspaces[0].csi",
 				"workspaces[0].emptydir",
+				"workspaces[0].oci",
 				"workspaces[0].persistentvolumeclaim",
+				"workspaces[0].projected",
 				"workspaces[0].secret",
 				"workspaces[0].volumeclaimtemplate",
 			},
 		},
+	}, {
+		name: "duplicate step overrides in taskRunSpecs",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "mytask",
+				StepOverrides:    []v1beta1.TaskRunStepOverride{{Name: "build"}, {Name: "build"}},
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("taskRunSpecs[0].stepOverrides[1].name"),
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
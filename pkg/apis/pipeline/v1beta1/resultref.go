@@ -106,6 +106,12 @@ func GetVarSubstitutionExpressionsForPipelineResult(result PipelineResult) ([]st
 	return allExpressions, len(allExpressions) != 0
 }
 
+// GetVarSubstitutionExpressionsForCacheKey extracts all the value between "$(" and ")"" for the key of a cache
+func GetVarSubstitutionExpressionsForCacheKey(key string) ([]string, bool) {
+	allExpressions := validateString(key)
+	return allExpressions, len(allExpressions) != 0
+}
+
 func validateString(value string) []string {
 	expressions := variableSubstitutionRegex.FindAllString(value, -1)
 	if expressions == nil {
@@ -0,0 +1,350 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+// ScriptParamsAsEnvAnnotation is the annotation a Task (or TaskRun) sets to
+// "true" or "false" to override whether the params referenced in its scripts
+// are passed as environment variables, as configured by the
+// "enable-script-param-env-vars" feature flag.
+const ScriptParamsAsEnvAnnotation = "tekton.dev/script-params-as-env"
+
+// scriptParamEnvPrefix prefixes the names of the environment variables
+// holding the values of the params referenced in scripts.
+const scriptParamEnvPrefix = "PARAMS_"
+
+var (
+	scriptParamRegex = regexp.MustCompile(`\$\((?:inputs\.)?params\.([_a-zA-Z][_a-zA-Z0-9.-]*)\)`)
+	envNameRegex     = regexp.MustCompile(`[^A-Z0-9_]`)
+
+	// shells are the interpreters whose scripts can reference environment
+	// variables with ${NAME}.
+	shells = map[string]bool{"sh": true, "bash": true, "ash": true, "dash": true, "ksh": true, "mksh": true, "zsh": true}
+)
+
+// ScriptParamsAsEnv returns whether the params referenced in the scripts of
+// the Task or TaskRun with the given annotations should be passed as
+// environment variables.
+func ScriptParamsAsEnv(ctx context.Context, annotations map[string]string) bool {
+	if v, ok := annotations[ScriptParamsAsEnvAnnotation]; ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return config.FromContextOrDefaults(ctx).FeatureFlags.EnableScriptParamEnvVars
+}
+
+// ScriptParamEnvName returns the name of the environment variable holding the
+// value of the param when it's referenced in a script.
+func ScriptParamEnvName(param string) string {
+	return scriptParamEnvPrefix + envNameRegex.ReplaceAllString(strings.ToUpper(param), "_")
+}
+
+// ScriptQuoting is how a shell script quotes a reference to a param, which
+// determines how the reference to an environment variable replacing it must
+// be written for the shell to expand it to the param's value verbatim.
+type ScriptQuoting int
+
+const (
+	// Unquoted references are subject to word splitting and globbing.
+	Unquoted ScriptQuoting = iota
+	// DoubleQuoted references, also in the body of a here-document whose
+	// delimiter isn't quoted, are expanded as a single word.
+	DoubleQuoted
+	// SingleQuoted references, in '...', are not expanded.
+	SingleQuoted
+	// ANSICQuoted references, in $'...', are not expanded.
+	ANSICQuoted
+	// Literal references, in the body of a here-document whose delimiter
+	// is quoted, are not expanded, and can't be made to be.
+	Literal
+)
+
+// ReplaceScriptParams replaces the references to params in the script with
+// the string returned by replace for the name of each param and how the
+// script quotes the reference.
+func ReplaceScriptParams(script string, replace func(ref, param string, quoting ScriptQuoting) string) string {
+	matches := scriptParamRegex.FindAllStringSubmatchIndex(script, -1)
+	if len(matches) == 0 {
+		return script
+	}
+	starts := make([]int, len(matches))
+	for i, m := range matches {
+		starts[i] = m[0]
+	}
+	quotings := scriptQuotings(script, starts)
+	var b strings.Builder
+	last := 0
+	for i, m := range matches {
+		b.WriteString(script[last:m[0]])
+		b.WriteString(replace(script[m[0]:m[1]], script[m[2]:m[3]], quotings[i]))
+		last = m[1]
+	}
+	b.WriteString(script[last:])
+	return b.String()
+}
+
+// heredoc is a here-document whose body starts on the next line.
+type heredoc struct {
+	delimiter string
+	quoted    bool
+	stripTabs bool
+}
+
+var heredocRegex = regexp.MustCompile(`^<<(-?)[ \t]*((?:[^\s;&|<>()]|\\.)+)`)
+
+// scriptQuotings returns how the shell script quotes the text at each of the
+// sorted offsets. It only follows the quotes and here-documents of the script,
+// so quotes nested in command substitutions within double quotes confuse it.
+func scriptQuotings(script string, offsets []int) []ScriptQuoting {
+	quotings := make([]ScriptQuoting, 0, len(offsets))
+	state := Unquoted
+	var pending []heredoc
+	var body *heredoc
+	for i := 0; i < len(script) && len(quotings) < len(offsets); i++ {
+		if body != nil {
+			// At the start of a line of the body of a here-document.
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			line := script[i : i+end]
+			if body.stripTabs {
+				line = strings.TrimLeft(line, "\t")
+			}
+			q := DoubleQuoted
+			if body.quoted {
+				q = Literal
+			}
+			for len(quotings) < len(offsets) && offsets[len(quotings)] <= i+end {
+				quotings = append(quotings, q)
+			}
+			if line == body.delimiter {
+				body = nil
+				if len(pending) > 0 {
+					body, pending = &pending[0], pending[1:]
+				}
+			}
+			i += end
+			continue
+		}
+		for len(quotings) < len(offsets) && offsets[len(quotings)] <= i {
+			quotings = append(quotings, state)
+		}
+		c := script[i]
+		switch state {
+		case Unquoted:
+			switch {
+			case c == '\\':
+				i++
+			case c == '\'':
+				state = SingleQuoted
+			case c == '"':
+				state = DoubleQuoted
+			case c == '#' && (i == 0 || strings.IndexByte(" \t\n;&|()", script[i-1]) >= 0):
+				// Skip the comment, up to the end of the line.
+				if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+					i += end - 1
+				} else {
+					i = len(script)
+				}
+			case c == '$' && strings.HasPrefix(script[i+1:], "'"):
+				state = ANSICQuoted
+				i++
+			case c == '<' && strings.HasPrefix(script[i:], "<<<"):
+				i += 2
+			case c == '<':
+				if m := heredocRegex.FindStringSubmatch(script[i:]); m != nil {
+					delimiter := m[2]
+					quoted := strings.ContainsAny(delimiter, "'\"\\")
+					if quoted {
+						delimiter = strings.NewReplacer("'", "", "\"", "", "\\", "").Replace(delimiter)
+					}
+					pending = append(pending, heredoc{delimiter: delimiter, quoted: quoted, stripTabs: m[1] == "-"})
+					i += len(m[0]) - 1
+				}
+			case c == '\n' && len(pending) > 0:
+				body, pending = &pending[0], pending[1:]
+			}
+		case DoubleQuoted:
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				state = Unquoted
+			}
+		case SingleQuoted:
+			if c == '\'' {
+				state = Unquoted
+			}
+		case ANSICQuoted:
+			switch c {
+			case '\\':
+				i++
+			case '\'':
+				state = Unquoted
+			}
+		}
+	}
+	for len(quotings) < len(offsets) {
+		quotings = append(quotings, state)
+	}
+	return quotings
+}
+
+// IsShellScript returns whether the script is run by a shell: either it has
+// no shebang, and so runs with the default /bin/sh, or its shebang names one.
+func IsShellScript(script string) bool {
+	cleaned := strings.TrimSpace(script)
+	if !strings.HasPrefix(cleaned, "#!") {
+		return true
+	}
+	shebang := strings.Fields(strings.SplitN(cleaned[2:], "\n", 2)[0])
+	if len(shebang) == 0 {
+		return false
+	}
+	interpreter := filepath.Base(shebang[0])
+	if interpreter == "env" {
+		// e.g. "#!/usr/bin/env bash", possibly with flags for env.
+		interpreter = ""
+		for _, arg := range shebang[1:] {
+			if !strings.HasPrefix(arg, "-") {
+				interpreter = filepath.Base(arg)
+				break
+			}
+		}
+	}
+	return shells[interpreter]
+}
+
+// scriptEnvParams returns the string params of the TaskSpec referenced in the
+// script that are passed as environment variables if it's a shell script.
+func scriptEnvParams(ts *TaskSpec, script string) []string {
+	if script == "" || !IsShellScript(script) {
+		return nil
+	}
+	var params []string
+	ReplaceScriptParams(script, func(ref, param string, quoting ScriptQuoting) string {
+		if quoting == Literal {
+			// The value is substituted, since the shell won't
+			// expand a variable there.
+			return ref
+		}
+		for _, p := range ts.Params {
+			if p.Name == param && p.Type != ParamTypeArray {
+				params = append(params, p.Name)
+			}
+		}
+		return ref
+	})
+	return params
+}
+
+// ScriptsInterpolatingParams returns the paths of the steps and sidecars
+// whose scripts reference params that will be substituted into the scripts'
+// text, which lets the params' values inject code into the scripts. If asEnv
+// is true, the string params referenced in shell scripts are passed as
+// environment variables instead, and so aren't substituted.
+func ScriptsInterpolatingParams(ts *TaskSpec, asEnv bool) []string {
+	interpolates := func(script string) bool {
+		refs := len(scriptParamRegex.FindAllString(script, -1))
+		if asEnv {
+			refs -= len(scriptEnvParams(ts, script))
+		}
+		return refs > 0
+	}
+	var paths []string
+	for i, s := range ts.Steps {
+		if interpolates(s.Script) {
+			paths = append(paths, fmt.Sprintf("steps[%d]", i))
+		}
+	}
+	for i, s := range ts.Sidecars {
+		if interpolates(s.Script) {
+			paths = append(paths, fmt.Sprintf("sidecars[%d]", i))
+		}
+	}
+	return paths
+}
+
+// ValidateScriptParamsAsEnv validates that the string params of the TaskSpec
+// can be passed to its shell scripts as environment variables: no two of them
+// share the name of their environment variable, whether the scripts reference
+// them or not, and the steps and sidecars don't declare an environment
+// variable with the name of a param their script references themselves.
+func ValidateScriptParamsAsEnv(ts *TaskSpec) (errs *apis.FieldError) {
+	byEnvName := map[string]string{}
+	for i, p := range ts.Params {
+		if p.Type == ParamTypeArray {
+			continue
+		}
+		name := ScriptParamEnvName(p.Name)
+		if other, ok := byEnvName[name]; ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("params %q and %q are both passed to scripts as the environment variable %s", other, p.Name, name), "name").ViaFieldIndex("params", i))
+		}
+		byEnvName[name] = p.Name
+	}
+	checkEnv := func(script string, env []corev1.EnvVar) *apis.FieldError {
+		var errs *apis.FieldError
+		for _, param := range scriptEnvParams(ts, script) {
+			name := ScriptParamEnvName(param)
+			for i, e := range env {
+				if e.Name == name {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("environment variable %s is reserved for param %q referenced in the script", name, param), "name").ViaFieldIndex("env", i))
+				}
+			}
+		}
+		return errs
+	}
+	for i, s := range ts.Steps {
+		errs = errs.Also(checkEnv(s.Script, s.Env).ViaFieldIndex("steps", i))
+		if ts.StepTemplate != nil {
+			errs = errs.Also(checkEnv(s.Script, ts.StepTemplate.Env).ViaField("stepTemplate"))
+		}
+	}
+	for i, s := range ts.Sidecars {
+		errs = errs.Also(checkEnv(s.Script, s.Env).ViaFieldIndex("sidecars", i))
+	}
+	return errs
+}
+
+// validateScriptParams validates the params referenced in the scripts of the
+// TaskSpec of a Task or TaskRun with the given annotations, and warns about
+// the scripts the params are substituted into.
+func validateScriptParams(ctx context.Context, annotations map[string]string, ts *TaskSpec) *apis.FieldError {
+	asEnv := ScriptParamsAsEnv(ctx, annotations)
+	if paths := ScriptsInterpolatingParams(ts, asEnv); len(paths) > 0 {
+		logging.FromContext(ctx).Warnf("Params are substituted into the scripts of %s, so their values may inject code; pass them as environment variables instead", strings.Join(paths, ", "))
+	}
+	if !asEnv {
+		return nil
+	}
+	return ValidateScriptParamsAsEnv(ts)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestScriptParamEnvName(t *testing.T) {
+	for _, tc := range []struct {
+		param string
+		want  string
+	}{
+		{param: "foo", want: "PARAMS_FOO"},
+		{param: "pr-title", want: "PARAMS_PR_TITLE"},
+		{param: "a.b_c", want: "PARAMS_A_B_C"},
+	} {
+		if got := v1beta1.ScriptParamEnvName(tc.param); got != tc.want {
+			t.Errorf("ScriptParamEnvName(%q) = %q, want %q", tc.param, got, tc.want)
+		}
+	}
+}
+
+func TestIsShellScript(t *testing.T) {
+	for _, tc := range []struct {
+		script string
+		want   bool
+	}{
+		{script: "echo hello", want: true},
+		{script: "\n  #!/bin/bash\necho hello", want: true},
+		{script: "#!/bin/sh -e\necho hello", want: true},
+		{script: "#!/usr/bin/env -S zsh -e\necho hello", want: true},
+		{script: "#!/usr/bin/env python3\nprint('hello')", want: false},
+		{script: "#!/usr/bin/node\nconsole.log('hello')", want: false},
+		{script: "#!\necho hello", want: false},
+	} {
+		if got := v1beta1.IsShellScript(tc.script); got != tc.want {
+			t.Errorf("IsShellScript(%q) = %t, want %t", tc.script, got, tc.want)
+		}
+	}
+}
+
+func TestScriptParamsAsEnv(t *testing.T) {
+	enabled := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableScriptParamEnvVars: true},
+	})
+	for _, tc := range []struct {
+		name        string
+		ctx         context.Context
+		annotations map[string]string
+		want        bool
+	}{{
+		name: "default",
+		ctx:  context.Background(),
+		want: false,
+	}, {
+		name: "feature flag",
+		ctx:  enabled,
+		want: true,
+	}, {
+		name:        "annotation enables",
+		ctx:         context.Background(),
+		annotations: map[string]string{v1beta1.ScriptParamsAsEnvAnnotation: "true"},
+		want:        true,
+	}, {
+		name:        "annotation disables",
+		ctx:         enabled,
+		annotations: map[string]string{v1beta1.ScriptParamsAsEnvAnnotation: "false"},
+		want:        false,
+	}, {
+		name:        "invalid annotation",
+		ctx:         enabled,
+		annotations: map[string]string{v1beta1.ScriptParamsAsEnvAnnotation: "maybe"},
+		want:        true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := v1beta1.ScriptParamsAsEnv(tc.ctx, tc.annotations); got != tc.want {
+				t.Errorf("ScriptParamsAsEnv() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScriptsInterpolatingParams(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{Name: "pr-title"}, {Name: "args", Type: v1beta1.ParamTypeArray}},
+		Steps: []v1beta1.Step{{
+			Script: `echo "$(params.pr-title)"`,
+		}, {
+			Script: "#!/usr/bin/env python3\nprint('$(params.pr-title)')",
+		}, {
+			Script: "#!/bin/sh\necho $(params.args) $(params.pr-title)",
+		}, {
+			Script: "echo hello",
+		}, {
+			// The shell doesn't expand variables in here-documents
+			// with a quoted delimiter.
+			Script: "cat <<'EOF'\n$(params.pr-title)\nEOF\necho '$(params.pr-title)'",
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Script: "#!/bin/bash\necho $(params.pr-title)",
+		}},
+	}
+	want := []string{"steps[1]", "steps[2]", "steps[4]"}
+	if d := cmp.Diff(want, v1beta1.ScriptsInterpolatingParams(spec, true)); d != "" {
+		t.Errorf("ScriptsInterpolatingParams() %s", diff.PrintWantGot(d))
+	}
+	want = []string{"steps[0]", "steps[1]", "steps[2]", "steps[4]", "sidecars[0]"}
+	if d := cmp.Diff(want, v1beta1.ScriptsInterpolatingParams(spec, false)); d != "" {
+		t.Errorf("ScriptsInterpolatingParams() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateScriptParamsAsEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		spec    *v1beta1.TaskSpec
+		wantErr *apis.FieldError
+	}{{
+		name: "params with distinct environment variables",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo-bar"}, {Name: "baz"}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Env: []corev1.EnvVar{{Name: "PARAMS_OTHER"}}},
+				Script:    "echo $(params.foo-bar) $(params.baz) $(params.foo-bar)",
+			}},
+		},
+	}, {
+		name: "colliding array params",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo-bar", Type: v1beta1.ParamTypeArray}, {Name: "foo_bar"}},
+			Steps: []v1beta1.Step{{
+				Script: "echo $(params.foo_bar)",
+			}},
+		},
+	}, {
+		name: "colliding params that aren't referenced in shell scripts",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "pr-title"}, {Name: "pr_title"}, {Name: "pr.title"}},
+			Steps: []v1beta1.Step{{
+				Script: "#!/usr/bin/env python3\nprint('$(params.pr-title) $(params.pr_title)')",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`params "pr-title" and "pr_title" are both passed to scripts as the environment variable PARAMS_PR_TITLE`, "params[1].name").Also(
+			apis.ErrGeneric(`params "pr_title" and "pr.title" are both passed to scripts as the environment variable PARAMS_PR_TITLE`, "params[2].name")),
+	}, {
+		name: "params with the same environment variable",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo-bar"}, {Name: "foo.bar"}},
+			Steps: []v1beta1.Step{{
+				Script: "echo $(params.foo-bar)",
+			}, {
+				Script: "echo $(params.foo.bar)",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`params "foo-bar" and "foo.bar" are both passed to scripts as the environment variable PARAMS_FOO_BAR`, "params[1].name"),
+	}, {
+		name: "step declaring the environment variable of a param",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo"}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Env: []corev1.EnvVar{{Name: "BAR"}, {Name: "PARAMS_FOO"}}},
+				Script:    "echo $(params.foo)",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`environment variable PARAMS_FOO is reserved for param "foo" referenced in the script`, "steps[0].env[1].name"),
+	}, {
+		name: "step template declaring the environment variable of a param",
+		spec: &v1beta1.TaskSpec{
+			Params:       []v1beta1.ParamSpec{{Name: "foo"}},
+			StepTemplate: &corev1.Container{Env: []corev1.EnvVar{{Name: "PARAMS_FOO"}}},
+			Steps: []v1beta1.Step{{
+				Script: "echo $(params.foo)",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`environment variable PARAMS_FOO is reserved for param "foo" referenced in the script`, "stepTemplate.env[0].name"),
+	}, {
+		name: "sidecar declaring the environment variable of a param",
+		spec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo"}},
+			Steps:  []v1beta1.Step{{Script: "echo hello"}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container: corev1.Container{Env: []corev1.EnvVar{{Name: "PARAMS_FOO"}}},
+				Script:    "echo $(params.foo)",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`environment variable PARAMS_FOO is reserved for param "foo" referenced in the script`, "sidecars[0].env[0].name"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1beta1.ValidateScriptParamsAsEnv(tc.spec)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateScriptParamsAsEnv() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidateScriptParamsAsEnv(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo-bar", Type: v1beta1.ParamTypeString}, {Name: "foo_bar", Type: v1beta1.ParamTypeString}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "busybox"},
+				Script:    "echo $(params.foo-bar) $(params.foo_bar)",
+			}},
+		},
+	}
+	if err := task.Validate(context.Background()); err != nil {
+		t.Errorf("Task.Validate() unexpected error when params are substituted into scripts: %v", err)
+	}
+	task.Annotations = map[string]string{v1beta1.ScriptParamsAsEnvAnnotation: "true"}
+	if err := task.Validate(context.Background()); err == nil {
+		t.Error("Task.Validate() expected an error when params are passed to scripts as the same environment variable")
+	}
+}
@@ -104,6 +104,12 @@ type TaskSpec struct {
 
 	// Results are values that this Task can output
 	Results []TaskResult `json:"results,omitempty"`
+
+	// ComputeResources are the compute resources the Task's Steps need
+	// altogether. They're distributed across the Steps' containers, which then
+	// can't specify resources of their own.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
 }
 
 // TaskResult used to describe the results of a task
@@ -125,9 +131,52 @@ type Step struct {
 	//
 	// If Script is not empty, the Step cannot have an Command and the Args will be passed to the Script.
 	Script string `json:"script,omitempty"`
+	// ScriptRef refers to a script stored outside of the Task, to run as if
+	// its contents were specified in Script. The script is loaded when the
+	// TaskRun's Pod is created.
+	// +optional
+	ScriptRef *ScriptRef `json:"scriptRef,omitempty"`
 	// Timeout is the time after which the step times out. Defaults to never.
 	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// InactivityTimeout is how long the Step may run without writing to stdout
+	// or stderr before it is stopped and fails. Defaults to never.
+	// +optional
+	InactivityTimeout *metav1.Duration `json:"inactivityTimeout,omitempty"`
+	// Retries is the number of times the Step's command is retried if it fails.
+	// Defaults to 0, meaning the command is run only once.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+	// RetryDelay is how long to wait before each retry of the Step's command.
+	// Defaults to retrying immediately.
+	// +optional
+	RetryDelay *metav1.Duration `json:"retryDelay,omitempty"`
+	// Group is the name of a group of consecutive Steps that run concurrently.
+	// The Steps in a group all start once the Steps before the group have
+	// completed, and the Steps after the group start once all of the Steps in
+	// the group have completed. If a Step in the group fails, the other Steps
+	// in the group still run to completion, and the Steps after the group are
+	// skipped.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Workspaces is a list of the Task's workspaces that this Step mounts.
+	// If empty, the Step mounts all of the Task's bound workspaces.
+	// +optional
+	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
+}
+
+// ScriptRef refers to the script a Step runs. Exactly one of its fields
+// must be set.
+type ScriptRef struct {
+	// ConfigMapKeyRef selects the key of a ConfigMap, in the TaskRun's
+	// namespace, holding the script.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// BundleFile is the name of the file holding the script in the Tekton
+	// Bundle the Task is fetched from.
+	// +optional
+	BundleFile string `json:"bundleFile,omitempty"`
 }
 
 // Sidecar has nearly the same data structure as Step, consisting of a Container and an optional Script, but does not have the ability to timeout.
@@ -138,6 +187,11 @@ type Sidecar struct {
 	//
 	// If Script is not empty, the Step cannot have an Command or Args.
 	Script string `json:"script,omitempty"`
+
+	// Workspaces is a list of the Task's workspaces that this Sidecar mounts.
+	// If empty, the Sidecar does not mount any workspaces.
+	// +optional
+	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -35,6 +35,7 @@ var _ apis.Validatable = (*Task)(nil)
 
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validateScriptParams(ctx, t.Annotations, &t.Spec).ViaField("spec"))
 	return errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -44,6 +45,13 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(ValidateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
+	errs = errs.Also(validateWorkspaceUsages(ts.Workspaces, ts.Steps, ts.Sidecars))
+	if ts.ComputeResources != nil {
+		// Check the Steps' own resources before they're merged with the
+		// StepTemplate.
+		errs = errs.Also(validateComputeResources(ts.ComputeResources).ViaField("computeResources"))
+		errs = errs.Also(validateNoStepResources(ts.StepTemplate, ts.Steps))
+	}
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -63,6 +71,49 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// computeResourceNames are the resources that compute resources can be
+// specified for.
+var computeResourceNames = sets.NewString(
+	string(corev1.ResourceCPU),
+	string(corev1.ResourceMemory),
+	string(corev1.ResourceEphemeralStorage),
+)
+
+// validateComputeResources checks that the compute resources only specify
+// resources that can be distributed across the Steps' containers, and don't
+// request more than their limits.
+func validateComputeResources(r *corev1.ResourceRequirements) (errs *apis.FieldError) {
+	for name := range r.Requests {
+		if !computeResourceNames.Has(string(name)) {
+			errs = errs.Also(apis.ErrInvalidKeyName(string(name), "requests", fmt.Sprintf("must be one of %v", computeResourceNames.List())))
+		}
+	}
+	for name, limit := range r.Limits {
+		if !computeResourceNames.Has(string(name)) {
+			errs = errs.Also(apis.ErrInvalidKeyName(string(name), "limits", fmt.Sprintf("must be one of %v", computeResourceNames.List())))
+			continue
+		}
+		if request, ok := r.Requests[name]; ok && request.Cmp(limit) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s request %s must be less than or equal to its limit %s", name, request.String(), limit.String()), "requests"))
+		}
+	}
+	return errs
+}
+
+// validateNoStepResources checks that neither the Steps nor the StepTemplate
+// specify resources, which are set from the Task's compute resources instead.
+func validateNoStepResources(stepTemplate *corev1.Container, steps []Step) (errs *apis.FieldError) {
+	if stepTemplate != nil && (len(stepTemplate.Resources.Requests) > 0 || len(stepTemplate.Resources.Limits) > 0) {
+		errs = errs.Also(apis.ErrGeneric("resources cannot be used with computeResources", "stepTemplate.resources"))
+	}
+	for idx, s := range steps {
+		if len(s.Resources.Requests) > 0 || len(s.Resources.Limits) > 0 {
+			errs = errs.Also(apis.ErrGeneric("resources cannot be used with computeResources", "resources").ViaFieldIndex("steps", idx))
+		}
+	}
+	return errs
+}
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
@@ -110,6 +161,36 @@ func ValidateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 	return errs
 }
 
+// validateWorkspaceUsages validates that the workspaces used by the Steps and
+// Sidecars of a Task are declared by the Task and are not listed more than once.
+func validateWorkspaceUsages(workspaces []WorkspaceDeclaration, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	wsNames := sets.NewString()
+	for _, w := range workspaces {
+		wsNames.Insert(w.Name)
+	}
+	for idx, step := range steps {
+		errs = errs.Also(validateWorkspaceUsage(wsNames, step.Workspaces).ViaFieldIndex("steps", idx))
+	}
+	for idx, sidecar := range sidecars {
+		errs = errs.Also(validateWorkspaceUsage(wsNames, sidecar.Workspaces).ViaFieldIndex("sidecars", idx))
+	}
+	return errs
+}
+
+func validateWorkspaceUsage(wsNames sets.String, usages []WorkspaceUsage) (errs *apis.FieldError) {
+	used := sets.NewString()
+	for idx, u := range usages {
+		if !wsNames.Has(u.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", u.Name), "name").ViaFieldIndex("workspaces", idx))
+		}
+		if used.Has(u.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q is used more than once", u.Name), "name").ViaFieldIndex("workspaces", idx))
+		}
+		used.Insert(u.Name)
+	}
+	return errs
+}
+
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
 	vols := sets.NewString()
@@ -129,9 +210,50 @@ func validateSteps(steps []Step) (errs *apis.FieldError) {
 	for idx, s := range steps {
 		errs = errs.Also(validateStep(s, names).ViaIndex(idx))
 	}
+	return errs.Also(validateStepGroups(steps))
+}
+
+// validateStepGroups checks that the Steps in each group are consecutive, since
+// a group runs as a single stage between the Steps before and after it.
+func validateStepGroups(steps []Step) (errs *apis.FieldError) {
+	ended := sets.NewString()
+	prevGroup := ""
+	for idx, s := range steps {
+		if s.Group != prevGroup && prevGroup != "" {
+			ended.Insert(prevGroup)
+		}
+		if s.Group != "" {
+			if ended.Has(s.Group) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the steps in group %q must be consecutive", s.Group), "group").ViaIndex(idx))
+			}
+			if e := validation.IsDNS1123Label(s.Group); len(e) > 0 {
+				errs = errs.Also(apis.ErrInvalidValue(s.Group, "group").ViaIndex(idx))
+			}
+		}
+		prevGroup = s.Group
+	}
 	return errs
 }
 
+func (ref *ScriptRef) validate() (errs *apis.FieldError) {
+	switch {
+	case ref.ConfigMapKeyRef != nil && ref.BundleFile != "":
+		return apis.ErrMultipleOneOf("configMapKeyRef", "bundleFile")
+	case ref.ConfigMapKeyRef != nil:
+		if ref.ConfigMapKeyRef.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("configMapKeyRef.name"))
+		}
+		if ref.ConfigMapKeyRef.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("configMapKeyRef.key"))
+		}
+		return errs
+	case ref.BundleFile != "":
+		return nil
+	default:
+		return apis.ErrMissingOneOf("configMapKeyRef", "bundleFile")
+	}
+}
+
 func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 	if s.Image == "" {
 		errs = errs.Also(apis.ErrMissingField("Image"))
@@ -146,6 +268,19 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 		}
 	}
 
+	if s.ScriptRef != nil {
+		if s.Script != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("script", "scriptRef"))
+		}
+		if len(s.Command) > 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("scriptRef cannot be used with command"),
+				Paths:   []string{"scriptRef"},
+			})
+		}
+		errs = errs.Also(s.ScriptRef.validate().ViaField("scriptRef"))
+	}
+
 	if s.Name != "" {
 		if names.Has(s.Name) {
 			errs = errs.Also(apis.ErrInvalidValue(s.Name, "name"))
@@ -166,6 +301,20 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 		}
 	}
 
+	if s.InactivityTimeout != nil {
+		if s.InactivityTimeout.Duration < time.Duration(0) {
+			errs = errs.Also(apis.ErrInvalidValue(s.InactivityTimeout.Duration, "inactivityTimeout"))
+		}
+	}
+	if s.Retries < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(s.Retries, "retries"))
+	}
+	if s.RetryDelay != nil {
+		if s.RetryDelay.Duration < time.Duration(0) {
+			errs = errs.Also(apis.ErrInvalidValue(s.RetryDelay.Duration, "retryDelay"))
+		}
+	}
+
 	for j, vm := range s.VolumeMounts {
 		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
 			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
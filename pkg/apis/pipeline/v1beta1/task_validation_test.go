@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -56,12 +57,13 @@ var invalidSteps = []v1beta1.Step{{Container: corev1.Container{
 
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
-		Params       []v1beta1.ParamSpec
-		Resources    *v1beta1.TaskResources
-		Steps        []v1beta1.Step
-		StepTemplate *corev1.Container
-		Workspaces   []v1beta1.WorkspaceDeclaration
-		Results      []v1beta1.TaskResult
+		Params           []v1beta1.ParamSpec
+		Resources        *v1beta1.TaskResources
+		Steps            []v1beta1.Step
+		StepTemplate     *corev1.Container
+		Workspaces       []v1beta1.WorkspaceDeclaration
+		Results          []v1beta1.TaskResult
+		ComputeResources *corev1.ResourceRequirements
 	}
 	tests := []struct {
 		name   string
@@ -252,6 +254,24 @@ func TestTaskSpecValidate(t *testing.T) {
 				MountPath:   "some/path",
 			}},
 		},
+	}, {
+		name: "valid workspace usage in step",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+					Args:  []string{"arg"},
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "foo-workspace",
+				}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "foo-workspace",
+			}, {
+				Name: "bar-workspace",
+			}},
+		},
 	}, {
 		name: "valid result",
 		fields: fields{
@@ -318,16 +338,62 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "step groups",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "build", Image: "myimage"},
+			}, {
+				Container: corev1.Container{Name: "lint", Image: "myimage"},
+				Group:     "checks",
+			}, {
+				Container: corev1.Container{Name: "unit-tests", Image: "myimage"},
+				Group:     "checks",
+			}, {
+				Container: corev1.Container{Name: "publish", Image: "myimage"},
+			}},
+		},
+	}, {
+		name: "step script refs",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				ScriptRef: &v1beta1.ScriptRef{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+						Key:                  "build.sh",
+					},
+				},
+			}, {
+				Container: corev1.Container{Image: "myimage"},
+				ScriptRef: &v1beta1.ScriptRef{BundleFile: "scripts/test.sh"},
+			}},
+		},
+	}, {
+		name: "compute resources",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+			}},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1beta1.TaskSpec{
-				Params:       tt.fields.Params,
-				Resources:    tt.fields.Resources,
-				Steps:        tt.fields.Steps,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+				Params:           tt.fields.Params,
+				Resources:        tt.fields.Resources,
+				Steps:            tt.fields.Steps,
+				StepTemplate:     tt.fields.StepTemplate,
+				Workspaces:       tt.fields.Workspaces,
+				Results:          tt.fields.Results,
+				ComputeResources: tt.fields.ComputeResources,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -340,13 +406,14 @@ func TestTaskSpecValidate(t *testing.T) {
 
 func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
-		Params       []v1beta1.ParamSpec
-		Resources    *v1beta1.TaskResources
-		Steps        []v1beta1.Step
-		Volumes      []corev1.Volume
-		StepTemplate *corev1.Container
-		Workspaces   []v1beta1.WorkspaceDeclaration
-		Results      []v1beta1.TaskResult
+		Params           []v1beta1.ParamSpec
+		Resources        *v1beta1.TaskResources
+		Steps            []v1beta1.Step
+		Volumes          []corev1.Volume
+		StepTemplate     *corev1.Container
+		Workspaces       []v1beta1.WorkspaceDeclaration
+		Results          []v1beta1.TaskResult
+		ComputeResources *corev1.ResourceRequirements
 	}
 	tests := []struct {
 		name          string
@@ -901,6 +968,210 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
 			Paths:   []string{"workspaces[0].mountpath"},
 		},
+	}, {
+		name: "negative step retries",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Retries:   -1,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1",
+			Paths:   []string{"steps[0].retries"},
+		},
+	}, {
+		name: "step group not consecutive",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Group:     "checks",
+			}, {
+				Container: corev1.Container{Image: "myimage"},
+			}, {
+				Container: corev1.Container{Image: "myimage"},
+				Group:     "checks",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "the steps in group \"checks\" must be consecutive",
+			Paths:   []string{"steps[2].group"},
+		},
+	}, {
+		name: "invalid step group name",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Group:     "Checks!",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Checks!",
+			Paths:   []string{"steps[0].group"},
+		},
+	}, {
+		name: "step script and scriptRef",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Script:    "echo hello",
+				ScriptRef: &v1beta1.ScriptRef{BundleFile: "hello.sh"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps[0].script", "steps[0].scriptRef"},
+		},
+	}, {
+		name: "step scriptRef with command",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage", Command: []string{"sh"}},
+				ScriptRef: &v1beta1.ScriptRef{BundleFile: "hello.sh"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "scriptRef cannot be used with command",
+			Paths:   []string{"steps[0].scriptRef"},
+		},
+	}, {
+		name: "step empty scriptRef",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				ScriptRef: &v1beta1.ScriptRef{},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   []string{"steps[0].scriptRef.bundleFile", "steps[0].scriptRef.configMapKeyRef"},
+		},
+	}, {
+		name: "step scriptRef with both refs",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				ScriptRef: &v1beta1.ScriptRef{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+						Key:                  "build.sh",
+					},
+					BundleFile: "build.sh",
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps[0].scriptRef.bundleFile", "steps[0].scriptRef.configMapKeyRef"},
+		},
+	}, {
+		name: "step scriptRef missing configMap key",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				ScriptRef: &v1beta1.ScriptRef{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+					},
+				},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps[0].scriptRef.configMapKeyRef.key"},
+		},
+	}, {
+		name: "compute resources with step resources",
+		fields: fields{
+			StepTemplate: &corev1.Container{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+			}, {
+				Container: corev1.Container{
+					Image: "myimage",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			}},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "resources cannot be used with computeResources",
+			Paths:   []string{"stepTemplate.resources", "steps[1].resources"},
+		},
+	}, {
+		name: "compute resources request exceeds limit",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+			}},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: cpu request 2 must be less than or equal to its limit 1",
+			Paths:   []string{"computeResources.requests"},
+		},
+	}, {
+		name: "compute resources with extended resource",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+			}},
+			ComputeResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid key name \"nvidia.com/gpu\"",
+			Paths:   []string{"computeResources.limits"},
+			Details: "must be one of [cpu ephemeral-storage memory]",
+		},
+	}, {
+		name: "step uses undeclared workspace",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "missing-workspace",
+				}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "undefined workspace \"missing-workspace\"",
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}, {
+		name: "step uses workspace more than once",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "some-workspace",
+				}, {
+					Name: "some-workspace",
+				}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace \"some-workspace\" is used more than once",
+			Paths:   []string{"steps[0].workspaces[1].name"},
+		},
 	}, {
 		name: "result name not validate",
 		fields: fields{
@@ -947,13 +1218,14 @@ func TestTaskSpecValidateError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1beta1.TaskSpec{
-				Params:       tt.fields.Params,
-				Resources:    tt.fields.Resources,
-				Steps:        tt.fields.Steps,
-				Volumes:      tt.fields.Volumes,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+				Params:           tt.fields.Params,
+				Resources:        tt.fields.Resources,
+				Steps:            tt.fields.Steps,
+				Volumes:          tt.fields.Volumes,
+				StepTemplate:     tt.fields.StepTemplate,
+				Workspaces:       tt.fields.Workspaces,
+				Results:          tt.fields.Results,
+				ComputeResources: tt.fields.ComputeResources,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -25,6 +25,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -66,6 +67,52 @@ type TaskRunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// ComputeResources overrides the compute resources the Task's Steps need
+	// altogether, including any resources specified by the Steps themselves.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// StepOverrides overrides the configuration of the Task's Steps.
+	// +optional
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides overrides the configuration of the Task's Sidecars.
+	// +optional
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+}
+
+// TaskRunStepOverride is used to override the configuration of a Step in the
+// Task the TaskRun runs.
+type TaskRunStepOverride struct {
+	// Name is the name of the Step to override.
+	Name string `json:"name"`
+	// Resources are merged into the Step's compute resources, replacing the
+	// requests and limits specified for the same resources.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Env is merged into the Step's environment variables, replacing the
+	// variables with the same names.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom is appended to the Step's sources of environment variables.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+// TaskRunSidecarOverride is used to override the configuration of a Sidecar
+// in the Task the TaskRun runs.
+type TaskRunSidecarOverride struct {
+	// Name is the name of the Sidecar to override.
+	Name string `json:"name"`
+	// Resources are merged into the Sidecar's compute resources, replacing
+	// the requests and limits specified for the same resources.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Env is merged into the Sidecar's environment variables, replacing the
+	// variables with the same names.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom is appended to the Sidecar's sources of environment variables.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -218,6 +265,51 @@ type TaskRunStatusFields struct {
 
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+
+	// ResolvedScripts lists the scripts the Steps loaded from a ScriptRef,
+	// along with the digests of their contents.
+	// +optional
+	ResolvedScripts []ResolvedScript `json:"resolvedScripts,omitempty"`
+
+	// PodTemplate is the effective pod template used to create the TaskRun's
+	// Pod: the TaskRun's pod template merged into the default pod template.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// WorkspaceSnapshots lists the VolumeSnapshots taken of the workspaces
+	// when the TaskRun completed, and the ones that could not be taken.
+	// +optional
+	// +listType=atomic
+	WorkspaceSnapshots []WorkspaceSnapshotStatus `json:"workspaceSnapshots,omitempty"`
+}
+
+// WorkspaceSnapshotStatus describes a VolumeSnapshot taken of a workspace.
+type WorkspaceSnapshotStatus struct {
+	// Name is the name of the workspace.
+	Name string `json:"name"`
+	// PersistentVolumeClaimName is the name of the PersistentVolumeClaim
+	// the snapshot was taken of.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// VolumeSnapshotName is the name of the VolumeSnapshot. It can be restored
+	// by creating a PersistentVolumeClaim with it as dataSource. It is empty
+	// when the snapshot could not be taken.
+	// +optional
+	VolumeSnapshotName string `json:"volumeSnapshotName,omitempty"`
+	// Message explains why the snapshot could not be taken.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ResolvedScript describes the script a Step loaded from a ScriptRef.
+type ResolvedScript struct {
+	// Name is the name of the Step.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// ScriptRef is the reference the script was loaded from.
+	ScriptRef ScriptRef `json:"scriptRef"`
+	// Digest is the digest of the script's contents, in the form
+	// "sha256:<hex>", before any variables are substituted into it.
+	Digest string `json:"digest"`
 }
 
 // TaskRunResult used to describe the results of a task
@@ -276,6 +368,49 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// ExitCode is the exit code of the step's command. It is not set if the
+	// command never ran, e.g. because the step was skipped.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Attempts is the number of times the step's command was run, including
+	// retries.
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+	// Duration is how long the step's command ran for.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// TerminationReason is why the step finished: one of Completed, Error,
+	// Skipped, TimeoutExceeded or InactivityTimeoutExceeded.
+	// +optional
+	TerminationReason string `json:"terminationReason,omitempty"`
+	// ResourceUsage is the resources used by the step's command.
+	// +optional
+	ResourceUsage *StepResourceUsage `json:"resourceUsage,omitempty"`
+	// Progress is the last status line or percentage the step wrote to its
+	// progress file. It is updated while the step is running and when it
+	// finishes if step progress reporting is enabled.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+}
+
+// StepResourceUsage reports the resources used by a step's command, as
+// measured by the operating system.
+type StepResourceUsage struct {
+	// UserCPUTime is the CPU time spent running the command in user mode.
+	// +optional
+	UserCPUTime *metav1.Duration `json:"userCPUTime,omitempty"`
+	// SystemCPUTime is the CPU time spent running the command in kernel mode.
+	// +optional
+	SystemCPUTime *metav1.Duration `json:"systemCPUTime,omitempty"`
+	// MaxRSS is the peak resident set size of the command.
+	// +optional
+	MaxRSS *resource.Quantity `json:"maxRSS,omitempty"`
+	// FilesystemReads is the number of times the command read from the filesystem.
+	// +optional
+	FilesystemReads int64 `json:"filesystemReads,omitempty"`
+	// FilesystemWrites is the number of times the command wrote to the filesystem.
+	// +optional
+	FilesystemWrites int64 `json:"filesystemWrites,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
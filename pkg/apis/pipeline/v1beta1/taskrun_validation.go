@@ -33,6 +33,9 @@ var _ apis.Validatable = (*TaskRun)(nil)
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+	if tr.Spec.TaskSpec != nil {
+		errs = errs.Also(validateScriptParams(ctx, tr.Annotations, tr.Spec.TaskSpec).ViaField("spec.taskspec"))
+	}
 	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -75,6 +78,19 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validateParameters(ts.Params).ViaField("params"))
 	errs = errs.Also(validateWorkspaceBindings(ctx, ts.Workspaces).ViaField("workspaces"))
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
+	if ts.ComputeResources != nil {
+		errs = errs.Also(validateComputeResources(ts.ComputeResources).ViaField("computeResources"))
+	}
+	errs = errs.Also(ts.PodTemplate.Validate(ctx).ViaField("podTemplate"))
+	errs = errs.Also(validateStepOverrides(ts.StepOverrides).ViaField("stepOverrides"))
+	errs = errs.Also(validateSidecarOverrides(ts.SidecarOverrides).ViaField("sidecarOverrides"))
+	if ts.ComputeResources != nil {
+		for idx, o := range ts.StepOverrides {
+			if len(o.Resources.Requests) > 0 || len(o.Resources.Limits) > 0 {
+				errs = errs.Also(apis.ErrGeneric("resources cannot be used with computeResources", "resources").ViaFieldIndex("stepOverrides", idx))
+			}
+		}
+	}
 
 	if ts.Status != "" {
 		if ts.Status != TaskRunSpecStatusCancelled {
@@ -101,11 +117,51 @@ func validateWorkspaceBindings(ctx context.Context, wb []WorkspaceBinding) (errs
 		seen.Insert(w.Name)
 
 		errs = errs.Also(w.Validate(ctx).ViaIndex(idx))
+		if w.Cache != nil {
+			// Only PipelineRuns have PipelineTasks producing the results a cache key could reference.
+			if expressions, ok := GetVarSubstitutionExpressionsForCacheKey(w.Cache.Key); ok && len(NewResultRefs(expressions)) > 0 {
+				errs = errs.Also(apis.ErrInvalidValue("the cache key of a TaskRun can't reference task results", "cache.key").ViaIndex(idx))
+			}
+		}
 	}
 
 	return errs
 }
 
+// validateStepOverrides makes sure the Steps to override are named once each.
+func validateStepOverrides(overrides []TaskRunStepOverride) *apis.FieldError {
+	names := make([]string, 0, len(overrides))
+	for _, o := range overrides {
+		names = append(names, o.Name)
+	}
+	return validateOverrideNames(names)
+}
+
+// validateSidecarOverrides makes sure the Sidecars to override are named once
+// each.
+func validateSidecarOverrides(overrides []TaskRunSidecarOverride) *apis.FieldError {
+	names := make([]string, 0, len(overrides))
+	for _, o := range overrides {
+		names = append(names, o.Name)
+	}
+	return validateOverrideNames(names)
+}
+
+func validateOverrideNames(names []string) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for idx, name := range names {
+		if name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
+			continue
+		}
+		if seen.Has(name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("name").ViaIndex(idx))
+		}
+		seen.Insert(name)
+	}
+	return errs
+}
+
 func validateParameters(params []Param) (errs *apis.FieldError) {
 	// Template must not duplicate parameter names.
 	seen := sets.NewString()
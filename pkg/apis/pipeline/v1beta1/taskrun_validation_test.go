@@ -22,10 +22,12 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -112,6 +114,22 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrMultipleOneOf("spec.workspaces[1].name"),
+	}, {
+		name: "cache key referencing task results",
+		tr: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "taskname"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name: "workspace",
+					Cache: &v1beta1.WorkspaceCache{
+						Key:                 "go-$(tasks.hash.results.go-sum)",
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+					},
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("the cache key of a TaskRun can't reference task results", "spec.workspaces[0].cache.key"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -231,6 +249,54 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrInvalidValue("invalid bundle reference (could not parse reference: invalid reference)", "taskref.bundle"),
 		wc:      enableTektonOCIBundles(t),
+	}, {
+		name: "invalid compute resources",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("2Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("memory request 2Gi must be less than or equal to its limit 1Gi", "computeResources.requests"),
+	}, {
+		name: "invalid pod template",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			PodTemplate: &pod.Template{
+				HostAliases: []corev1.HostAlias{{IP: "not-an-ip", Hostnames: []string{"registry.local"}}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("not-an-ip", "podTemplate.hostAliases[0].ip"),
+	}, {
+		name: "step override without a name",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "my-task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{}},
+		},
+		wantErr: apis.ErrMissingField("stepOverrides[0].name"),
+	}, {
+		name: "duplicate sidecar overrides",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:          &v1beta1.TaskRef{Name: "my-task"},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "proxy"}, {Name: "proxy"}},
+		},
+		wantErr: apis.ErrMultipleOneOf("sidecarOverrides[1].name"),
+	}, {
+		name: "step override resources with compute resources",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+			},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("2Gi")},
+				},
+			}},
+		},
+		wantErr: apis.ErrGeneric("resources cannot be used with computeResources", "stepOverrides[0].resources"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -299,6 +365,22 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "step and sidecar overrides",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+				},
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{
+				Name: "proxy",
+				Env:  []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}},
+		},
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // WorkspaceDeclaration is a declaration of a volume that a Task requires.
@@ -50,6 +51,14 @@ func (w *WorkspaceDeclaration) GetMountPath() string {
 	return filepath.Join(pipeline.WorkspaceDir, w.Name)
 }
 
+// WorkspaceUsage is used by a Step or Sidecar to declare that it needs access
+// to one of the Task's declared workspaces. A Step or Sidecar that lists any
+// WorkspaceUsages will only have those workspaces mounted.
+type WorkspaceUsage struct {
+	// Name is the name of the Task's declared workspace to mount.
+	Name string `json:"name"`
+}
+
 // WorkspaceBinding maps a Task's declared workspace to a Volume.
 type WorkspaceBinding struct {
 	// Name is the name of the workspace populated by the volume.
@@ -58,10 +67,19 @@ type WorkspaceBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// ReadOnly mounts the workspace read-only, even if the Task declares it writable.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
 	// VolumeClaimTemplate is a template for a claim that will be created in the same namespace.
 	// The PipelineRun controller is responsible for creating a unique claim for each instance of PipelineRun.
 	// +optional
 	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+	// VolumeClaimRetentionPolicy defines when the claim created from the VolumeClaimTemplate
+	// is deleted, once the PipelineRun or TaskRun that owns it is done. When it is not set, the
+	// default-volume-claim-retention-policy from config-defaults is used, and claims are
+	// retained if there is none.
+	// +optional
+	VolumeClaimRetentionPolicy VolumeClaimRetentionPolicy `json:"volumeClaimRetentionPolicy,omitempty"`
 	// PersistentVolumeClaimVolumeSource represents a reference to a
 	// PersistentVolumeClaim in the same namespace. Either this OR EmptyDir can be used.
 	// +optional
@@ -77,8 +95,132 @@ type WorkspaceBinding struct {
 	// Secret represents a secret that should populate this workspace.
 	// +optional
 	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+	// Projected represents a projected volume combining several secrets,
+	// configMaps and other sources that should populate this workspace.
+	// +optional
+	Projected *corev1.ProjectedVolumeSource `json:"projected,omitempty"`
+	// CSI represents an ephemeral volume provided by a CSI driver, such as
+	// the secrets store CSI driver, that should populate this workspace.
+	// +optional
+	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+	// Cache represents a PersistentVolumeClaim that is shared by the runs using the
+	// same cache key, so that content downloaded by a run can be reused by later runs.
+	// +optional
+	Cache *WorkspaceCache `json:"cache,omitempty"`
+	// OCI represents an emptyDir that is populated from, and pushed to, OCI artifacts
+	// in a registry, so that its content is handed off between tasks without a shared volume.
+	// +optional
+	OCI *WorkspaceOCI `json:"oci,omitempty"`
+	// Git is a repository that is cloned into the volume of the workspace before the steps
+	// run. The fetched commit is exposed as $(workspaces.<name>.commit) in Pipelines.
+	// +optional
+	Git *WorkspaceGit `json:"git,omitempty"`
+	// Snapshot takes a CSI VolumeSnapshot of the PersistentVolumeClaim of the workspace when
+	// a TaskRun using it completes, so that its content can be restored for debugging. The
+	// claim is then retained, and can't use a retention policy deleting it.
+	// +optional
+	Snapshot *WorkspaceSnapshot `json:"snapshot,omitempty"`
+}
+
+// WorkspaceSnapshot configures the VolumeSnapshots taken of the PersistentVolumeClaim of a
+// workspace when the TaskRuns using it complete. The snapshots are owned by the TaskRuns and
+// their names are recorded in the status of the TaskRuns.
+type WorkspaceSnapshot struct {
+	// When is when the snapshot is taken: onFailure, the default, only when the TaskRun
+	// failed, and always whenever the TaskRun completes.
+	// +optional
+	When WorkspaceSnapshotPolicy `json:"when,omitempty"`
+	// VolumeSnapshotClassName is the VolumeSnapshotClass of the snapshots. The default
+	// class of the CSI driver is used when it is not set.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+	// Tasks is, in a PipelineRun, the list of PipelineTasks whose TaskRuns take the
+	// snapshots. All the PipelineTasks using the workspace take them when it is empty.
+	// +optional
+	// +listType=atomic
+	Tasks []string `json:"tasks,omitempty"`
+}
+
+// WorkspaceSnapshotPolicy defines when a VolumeSnapshot of a workspace is taken.
+type WorkspaceSnapshotPolicy string
+
+const (
+	// WorkspaceSnapshotOnFailure takes the snapshot when the TaskRun failed.
+	WorkspaceSnapshotOnFailure WorkspaceSnapshotPolicy = "onFailure"
+	// WorkspaceSnapshotAlways takes the snapshot whenever the TaskRun completes.
+	WorkspaceSnapshotAlways WorkspaceSnapshotPolicy = "always"
+)
+
+// WorkspaceGit is a Git repository that pre-populates a workspace.
+type WorkspaceGit struct {
+	// URL is the URL of the repository to clone.
+	URL string `json:"url"`
+	// Revision is the branch, tag or commit SHA to check out. The default branch of
+	// the repository is checked out when it is not set.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Refspec is the refspec to fetch the revision from.
+	// +optional
+	Refspec string `json:"refspec,omitempty"`
+	// Depth is the number of commits fetched, 1 when it is not set. The full history
+	// is fetched when it is 0.
+	// +optional
+	Depth *uint `json:"depth,omitempty"`
+}
+
+// WorkspaceOCI binds a workspace to an emptyDir whose content is handed off through OCI
+// artifacts in an image repository. In a PipelineRun only the Repository is set: each
+// PipelineTask pulls the artifacts pushed by the closest preceding PipelineTasks using the
+// same workspace, and pushes its own.
+type WorkspaceOCI struct {
+	// Repository is the image repository the artifacts are pushed to and pulled from,
+	// for example gcr.io/my-project/workspaces.
+	Repository string `json:"repository"`
+	// From is the list of tags in the repository that are pulled into the workspace, in
+	// order, before the steps run. Tags that do not exist are skipped.
+	// +optional
+	// +listType=atomic
+	From []string `json:"from,omitempty"`
+	// To is the tag in the repository the content of the workspace is pushed to after
+	// the steps ran.
+	// +optional
+	To string `json:"to,omitempty"`
 }
 
+// WorkspaceCache binds a workspace to a PersistentVolumeClaim looked up by a cache key.
+// The claim is created from the VolumeClaimTemplate, and is thus empty, the first time a
+// key is used, and it is reused by the later runs with the same key.
+type WorkspaceCache struct {
+	// Key identifies the content of the cache, for example a hash of go.sum. It can
+	// reference the params of the PipelineRun or TaskRun with $(params.<name>), and the
+	// results of the Pipeline's tasks with $(tasks.<task>.results.<result>).
+	Key string `json:"key"`
+	// VolumeClaimTemplate is a template for the claim created when no cache exists for
+	// the key yet. The name of the template, or else the name of the workspace, identifies
+	// the caches that are evicted together.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate"`
+	// MaxAge is the duration after which a cache that has not been used is deleted.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// MaxEntries is the number of caches with the same name that are kept. The least
+	// recently used ones are deleted first.
+	// +optional
+	MaxEntries int `json:"maxEntries,omitempty"`
+}
+
+// VolumeClaimRetentionPolicy defines when a PersistentVolumeClaim created from a
+// VolumeClaimTemplate is deleted.
+type VolumeClaimRetentionPolicy string
+
+const (
+	// VolumeClaimRetain keeps the claim as long as the PipelineRun or TaskRun that owns it exists.
+	VolumeClaimRetain VolumeClaimRetentionPolicy = "retain"
+	// VolumeClaimDeleteOnCompletion deletes the claim once the PipelineRun or TaskRun that owns it is done.
+	VolumeClaimDeleteOnCompletion VolumeClaimRetentionPolicy = "deleteOnCompletion"
+	// VolumeClaimDeleteOnSuccess deletes the claim once the PipelineRun or TaskRun that owns it has succeeded.
+	VolumeClaimDeleteOnSuccess VolumeClaimRetentionPolicy = "deleteOnSuccess"
+)
+
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
 // is expected to populate with a workspace binding.
 // Deprecated: use PipelineWorkspaceDeclaration type instead
@@ -110,4 +252,8 @@ type WorkspacePipelineTaskBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// ReadOnly mounts the workspace read-only into the Task, even if the Task
+	// declares it writable.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
 }
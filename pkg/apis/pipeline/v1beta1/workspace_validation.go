@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
@@ -31,6 +33,10 @@ var allVolumeSourceFields []string = []string{
 	"emptydir",
 	"configmap",
 	"secret",
+	"projected",
+	"csi",
+	"cache",
+	"oci",
 }
 
 // Validate looks at the Volume provided in wb and makes sure that it is valid.
@@ -51,6 +57,17 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrMissingOneOf(allVolumeSourceFields...)
 	}
 
+	if b.VolumeClaimRetentionPolicy != "" {
+		if b.VolumeClaimTemplate == nil {
+			return apis.ErrDisallowedFields("volumeClaimRetentionPolicy")
+		}
+		switch b.VolumeClaimRetentionPolicy {
+		case VolumeClaimRetain, VolumeClaimDeleteOnCompletion, VolumeClaimDeleteOnSuccess:
+		default:
+			return apis.ErrInvalidValue(b.VolumeClaimRetentionPolicy, "volumeClaimRetentionPolicy")
+		}
+	}
+
 	// For a PersistentVolumeClaim to work, you must at least provide the name of the PVC to use.
 	if b.PersistentVolumeClaim != nil && b.PersistentVolumeClaim.ClaimName == "" {
 		return apis.ErrMissingField("persistentvolumeclaim.claimname")
@@ -66,6 +83,90 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrMissingField("secret.secretName")
 	}
 
+	// For a Projected volume to work, you must provide at least one source.
+	if b.Projected != nil && len(b.Projected.Sources) == 0 {
+		return apis.ErrMissingField("projected.sources")
+	}
+
+	// For a CSI volume to work, you must provide the driver to use.
+	if b.CSI != nil && b.CSI.Driver == "" {
+		return apis.ErrMissingField("csi.driver")
+	}
+
+	if b.Cache != nil {
+		if err := b.Cache.validate(); err != nil {
+			return err.ViaField("cache")
+		}
+	}
+
+	if b.OCI != nil {
+		if err := b.OCI.validate(); err != nil {
+			return err.ViaField("oci")
+		}
+	}
+
+	if b.Git != nil {
+		// A repository can't be cloned into a volume that is always mounted read-only.
+		if b.ConfigMap != nil || b.Secret != nil || b.Projected != nil {
+			return apis.ErrDisallowedFields("git")
+		}
+		if b.Git.URL == "" {
+			return apis.ErrMissingField("git.url")
+		}
+	}
+
+	if b.Snapshot != nil {
+		// Only PersistentVolumeClaims can be snapshotted.
+		if b.PersistentVolumeClaim == nil && b.VolumeClaimTemplate == nil && b.Cache == nil {
+			return apis.ErrDisallowedFields("snapshot")
+		}
+		switch b.Snapshot.When {
+		case "", WorkspaceSnapshotOnFailure, WorkspaceSnapshotAlways:
+		default:
+			return apis.ErrInvalidValue(b.Snapshot.When, "snapshot.when")
+		}
+		// The claim must outlive the run until its snapshots are ready to use, so it can't be deleted with the run.
+		if b.VolumeClaimRetentionPolicy == VolumeClaimDeleteOnCompletion || b.VolumeClaimRetentionPolicy == VolumeClaimDeleteOnSuccess {
+			return apis.ErrGeneric(fmt.Sprintf("a snapshotted workspace can't use the %s retention policy", b.VolumeClaimRetentionPolicy), "snapshot", "volumeClaimRetentionPolicy")
+		}
+	}
+
+	return nil
+}
+
+func (c *WorkspaceCache) validate() *apis.FieldError {
+	if c.Key == "" {
+		return apis.ErrMissingField("key")
+	}
+	if c.VolumeClaimTemplate == nil {
+		return apis.ErrMissingField("volumeClaimTemplate")
+	}
+	if c.MaxAge != nil && c.MaxAge.Duration <= 0 {
+		return apis.ErrInvalidValue(c.MaxAge.Duration.String(), "maxAge")
+	}
+	if c.MaxEntries < 0 {
+		return apis.ErrInvalidValue(c.MaxEntries, "maxEntries")
+	}
+	return nil
+}
+
+func (o *WorkspaceOCI) validate() *apis.FieldError {
+	if o.Repository == "" {
+		return apis.ErrMissingField("repository")
+	}
+	if _, err := name.NewRepository(o.Repository); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "repository")
+	}
+	for i, tag := range o.From {
+		if _, err := name.NewTag(fmt.Sprintf("%s:%s", o.Repository, tag)); err != nil {
+			return apis.ErrInvalidValue(err.Error(), "from").ViaIndex(i)
+		}
+	}
+	if o.To != "" {
+		if _, err := name.NewTag(fmt.Sprintf("%s:%s", o.Repository, o.To)); err != nil {
+			return apis.ErrInvalidValue(err.Error(), "to")
+		}
+	}
 	return nil
 }
 
@@ -88,5 +189,17 @@ func (b *WorkspaceBinding) numSources() int {
 	if b.Secret != nil {
 		n++
 	}
+	if b.Projected != nil {
+		n++
+	}
+	if b.CSI != nil {
+		n++
+	}
+	if b.Cache != nil {
+		n++
+	}
+	if b.OCI != nil {
+		n++
+	}
 	return n
 }
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -79,6 +80,89 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				SecretName: "my-secret",
 			},
 		},
+	}, {
+		name: "Valid projected",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap-name"},
+					},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "Valid csi",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "secrets-store.csi.k8s.io",
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-database"},
+			},
+		},
+	}, {
+		name: "Valid volumeClaimTemplate with retention policy",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mypvc",
+				},
+			},
+			VolumeClaimRetentionPolicy: VolumeClaimDeleteOnSuccess,
+		},
+	}, {
+		name: "Valid cache",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Cache: &WorkspaceCache{
+				Key: "$(params.go-sum-hash)",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "go-modules",
+					},
+				},
+				MaxAge:     &metav1.Duration{Duration: 24 * time.Hour},
+				MaxEntries: 3,
+			},
+		},
+	}, {
+		name: "Valid oci",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			OCI: &WorkspaceOCI{
+				Repository: "gcr.io/my-project/workspaces",
+				From:       []string{"run-fetch"},
+				To:         "run-build",
+			},
+		},
+	}, {
+		name: "Valid git",
+		binding: &WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Git: &WorkspaceGit{
+				URL:      "https://github.com/tektoncd/pipeline",
+				Revision: "main",
+			},
+		},
+	}, {
+		name: "Valid snapshot",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pool-party",
+			},
+			Snapshot: &WorkspaceSnapshot{
+				When:                    WorkspaceSnapshotAlways,
+				VolumeSnapshotClassName: "csi-snapclass",
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := tc.binding.Validate(context.Background()); err != nil {
@@ -128,6 +212,144 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			Name:   "beth",
 			Secret: &corev1.SecretVolumeSource{},
 		},
+	}, {
+		name: "Provide projected without sources",
+		binding: &WorkspaceBinding{
+			Name:      "beth",
+			Projected: &corev1.ProjectedVolumeSource{},
+		},
+	}, {
+		name: "Provide csi without a driver",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			CSI:  &corev1.CSIVolumeSource{},
+		},
+	}, {
+		name: "Provided both secret and csi",
+		binding: &WorkspaceBinding{
+			Name:   "beth",
+			Secret: &corev1.SecretVolumeSource{SecretName: "my-secret"},
+			CSI:    &corev1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"},
+		},
+	}, {
+		name: "Provide retention policy without a volumeClaimTemplate",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pool-party",
+			},
+			VolumeClaimRetentionPolicy: VolumeClaimDeleteOnCompletion,
+		},
+	}, {
+		name: "Provide unknown retention policy",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mypvc",
+				},
+			},
+			VolumeClaimRetentionPolicy: "deleteSometimes",
+		},
+	}, {
+		name: "Provide cache without a key",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Cache: &WorkspaceCache{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			},
+		},
+	}, {
+		name: "Provide cache without a volumeClaimTemplate",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Cache: &WorkspaceCache{
+				Key: "$(params.go-sum-hash)",
+			},
+		},
+	}, {
+		name: "Provide cache with a negative maxAge",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Cache: &WorkspaceCache{
+				Key:                 "$(params.go-sum-hash)",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				MaxAge:              &metav1.Duration{Duration: -time.Hour},
+			},
+		},
+	}, {
+		name: "Provide cache with negative maxEntries",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Cache: &WorkspaceCache{
+				Key:                 "$(params.go-sum-hash)",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				MaxEntries:          -1,
+			},
+		},
+	}, {
+		name: "Provide oci without a repository",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			OCI:  &WorkspaceOCI{To: "run-build"},
+		},
+	}, {
+		name: "Provide oci with an invalid tag",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			OCI: &WorkspaceOCI{
+				Repository: "gcr.io/my-project/workspaces",
+				From:       []string{"not a tag"},
+			},
+		},
+	}, {
+		name: "Provide git without a volume",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			Git:  &WorkspaceGit{URL: "https://github.com/tektoncd/pipeline"},
+		},
+	}, {
+		name: "Provide git without a url",
+		binding: &WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Git:      &WorkspaceGit{Revision: "main"},
+		},
+	}, {
+		name: "Provide git with a configMap",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "foo"},
+			},
+			Git: &WorkspaceGit{URL: "https://github.com/tektoncd/pipeline"},
+		},
+	}, {
+		name: "Provide snapshot with an emptyDir",
+		binding: &WorkspaceBinding{
+			Name:     "beth",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Snapshot: &WorkspaceSnapshot{},
+		},
+	}, {
+		name: "Provide snapshot with an invalid when",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pool-party",
+			},
+			Snapshot: &WorkspaceSnapshot{When: "sometimes"},
+		},
+	}, {
+		name: "Provide snapshot with a claim deleted on completion",
+		binding: &WorkspaceBinding{
+			Name: "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+			},
+			VolumeClaimRetentionPolicy: VolumeClaimDeleteOnCompletion,
+			Snapshot:                   &WorkspaceSnapshot{},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := tc.binding.Validate(context.Background()); err == nil {
@@ -57,6 +57,10 @@ const (
 	// PipelineResourceTypeBuildGCS is the subtype for the BuildGCSResources, which is simialr to the GCSResource but
 	// with additional functionality that was added to be compatible with knative build.
 	PipelineResourceTypeBuildGCS PipelineResourceType = "build-gcs"
+
+	// PipelineResourceTypeS3 is the subtype for the S3Resources, which is backed by an object/prefix in an
+	// S3-compatible object store.
+	PipelineResourceTypeS3 PipelineResourceType = "s3"
 )
 
 // AllResourceTypes can be used for validation to check if a provided Resource type is one of the known types.
@@ -246,6 +246,10 @@ func TestAllowedGCSStorageType(t *testing.T) {
 			storageType: "build-gcs",
 			want:        true,
 		},
+		{name: "storage with s3 type",
+			storageType: "s3",
+			want:        true,
+		},
 		{name: "storage with incorrent type",
 			storageType: "t",
 			want:        false,
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const workspaceArtifactCommand = "/ko-app/workspace-artifact"
+
+// ArtifactOCI represents an image repository where artifacts are stored as OCI images
+// tagged in the repository.
+// +k8s:deepcopy-gen=true
+type ArtifactOCI struct {
+	Repository string
+
+	WorkspaceArtifactImage string
+}
+
+// GetType returns the type of the artifact storage.
+func (o *ArtifactOCI) GetType() string {
+	return pipeline.ArtifactStorageOCIType
+}
+
+// StorageBasePath returns the path to be used to store artifacts in a pipelinerun temporary storage.
+func (o *ArtifactOCI) StorageBasePath(pr *v1beta1.PipelineRun) string {
+	return pr.Name
+}
+
+// GetCopyFromStorageToSteps returns a container used to pull the artifact tagged sourcePath into destinationPath.
+func (o *ArtifactOCI) GetCopyFromStorageToSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
+	return []v1beta1.Step{{Container: corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-pull-%s", name)),
+		Image:   o.WorkspaceArtifactImage,
+		Command: []string{workspaceArtifactCommand},
+		Args:    []string{"-mode", "pull", "-ref", o.ref(sourcePath), "-path", destinationPath},
+	}}}
+}
+
+// GetCopyToStorageFromSteps returns a container used to push sourcePath as the artifact tagged destinationPath.
+func (o *ArtifactOCI) GetCopyToStorageFromSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
+	return []v1beta1.Step{{Container: corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-push-%s", name)),
+		Image:   o.WorkspaceArtifactImage,
+		Command: []string{workspaceArtifactCommand},
+		Args:    []string{"-mode", "push", "-ref", o.ref(destinationPath), "-path", sourcePath},
+	}}}
+}
+
+// GetSecretsVolumes returns nil because the credentials of the registry are read from
+// the docker config of the steps.
+func (o *ArtifactOCI) GetSecretsVolumes() []corev1.Volume {
+	return nil
+}
+
+func (o *ArtifactOCI) ref(tag string) string {
+	return fmt.Sprintf("%s:%s", o.Repository, tag)
+}
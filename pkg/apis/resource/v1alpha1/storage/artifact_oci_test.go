@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/storage"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestOCIGetCopyFromContainerSpec(t *testing.T) {
+	names.TestingSeed()
+
+	oci := storage.ArtifactOCI{
+		Repository:             "gcr.io/my-project/workspaces",
+		WorkspaceArtifactImage: "workspace-artifact",
+	}
+	want := []v1beta1.Step{{Container: corev1.Container{
+		Name:    "artifact-pull-workspace-9l9zj",
+		Image:   "workspace-artifact",
+		Command: []string{"/ko-app/workspace-artifact"},
+		Args:    []string{"-mode", "pull", "-ref", "gcr.io/my-project/workspaces:run-fetch", "-path", "/workspace/destination"},
+	}}}
+
+	got := oci.GetCopyFromStorageToSteps("workspace", "run-fetch", "/workspace/destination")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOCIGetCopyToContainerSpec(t *testing.T) {
+	names.TestingSeed()
+
+	oci := storage.ArtifactOCI{
+		Repository:             "gcr.io/my-project/workspaces",
+		WorkspaceArtifactImage: "workspace-artifact",
+	}
+	want := []v1beta1.Step{{Container: corev1.Container{
+		Name:    "artifact-push-workspace-9l9zj",
+		Image:   "workspace-artifact",
+		Command: []string{"/ko-app/workspace-artifact"},
+		Args:    []string{"-mode", "push", "-ref", "gcr.io/my-project/workspaces:run-build", "-path", "/workspace/source"},
+	}}}
+
+	got := oci.GetCopyToStorageFromSteps("workspace", "/workspace/source", "run-build")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
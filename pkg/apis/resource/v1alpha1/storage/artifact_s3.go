@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ArtifactS3 contains the configuration of an S3-compatible bucket, e.g. on AWS,
+// MinIO or Ceph RGW, defined in the Bucket config map.
+// +k8s:deepcopy-gen=true
+type ArtifactS3 struct {
+	Name           string
+	Location       string
+	Endpoint       string
+	Region         string
+	ForcePathStyle bool
+	Secrets        []resource.SecretParam
+
+	ShellImage  string
+	AwsCLIImage string
+}
+
+// GetType returns the type of the artifact storage
+func (b *ArtifactS3) GetType() string {
+	return pipeline.ArtifactStorageBucketType
+}
+
+// StorageBasePath returns the path to be used to store artifacts in a pipelinerun temporary storage
+func (b *ArtifactS3) StorageBasePath(pr *v1beta1.PipelineRun) string {
+	return fmt.Sprintf("%s-%s-bucket", pr.Name, pr.Namespace)
+}
+
+// GetCopyFromStorageToSteps returns a container used to download artifacts from temporary storage
+func (b *ArtifactS3) GetCopyFromStorageToSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	command, args := s3Command(b.Endpoint, b.Region, b.ForcePathStyle, "cp", "--recursive", b.url(sourcePath), destinationPath)
+
+	return []v1beta1.Step{{Container: corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-dest-mkdir-%s", name)),
+		Image:   b.ShellImage,
+		Command: []string{"mkdir", "-p", destinationPath},
+	}}, {
+		Container: corev1.Container{
+			Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
+			Image:        b.AwsCLIImage,
+			Command:      command,
+			Args:         args,
+			Env:          envVars,
+			VolumeMounts: secretVolumeMount,
+		},
+	}}
+}
+
+// GetCopyToStorageFromSteps returns a container used to upload artifacts for temporary storage
+func (b *ArtifactS3) GetCopyToStorageFromSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	command, args := s3Command(b.Endpoint, b.Region, b.ForcePathStyle, "cp", "--recursive", sourcePath, b.url(destinationPath))
+
+	return []v1beta1.Step{{
+		Container: corev1.Container{
+			Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-to-%s", name)),
+			Image:        b.AwsCLIImage,
+			Command:      command,
+			Args:         args,
+			Env:          envVars,
+			VolumeMounts: secretVolumeMount,
+		},
+	}}
+}
+
+// GetSecretsVolumes returns the list of volumes for secrets to be mounted
+// on pod
+func (b *ArtifactS3) GetSecretsVolumes() []corev1.Volume {
+	volumes := []corev1.Volume{}
+	for _, sec := range b.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("volume-bucket-%s", sec.SecretName),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: sec.SecretName,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+func (b *ArtifactS3) url(path string) string {
+	return fmt.Sprintf("%s/%s/", strings.TrimSuffix(b.Location, "/"), path)
+}
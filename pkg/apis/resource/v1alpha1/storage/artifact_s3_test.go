@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/storage"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var s3Bucket = storage.ArtifactS3{
+	Location:       "s3://fake-bucket",
+	Endpoint:       "http://minio:9000",
+	Region:         "us-east-1",
+	ForcePathStyle: true,
+	Secrets: []v1alpha1.SecretParam{{
+		FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+		SecretName: secretName,
+		SecretKey:  "credentials",
+	}},
+	ShellImage:  "busybox",
+	AwsCLIImage: "docker.io/amazon/aws-cli",
+}
+
+func TestS3GetCopyFromContainerSpec(t *testing.T) {
+	names.TestingSeed()
+
+	want := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "artifact-dest-mkdir-workspace-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/destination"},
+	}}, {
+		Container: corev1.Container{
+			Name:         "artifact-copy-from-workspace-mz4c7",
+			Image:        "docker.io/amazon/aws-cli",
+			Command:      []string{"bash"},
+			Args:         []string{"-c", s3PathStyleScript, "aws", "--endpoint-url", "http://minio:9000", "--region", "us-east-1", "s3", "cp", "--recursive", "s3://fake-bucket/src-path/", "/workspace/destination"},
+			Env:          []corev1.EnvVar{{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: fmt.Sprintf("/var/bucketsecret/%s/credentials", secretName)}},
+			VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
+		},
+	}}
+
+	got := s3Bucket.GetCopyFromStorageToSteps("workspace", "src-path", "/workspace/destination")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3GetCopyToContainerSpec(t *testing.T) {
+	names.TestingSeed()
+
+	want := []v1alpha1.Step{{
+		Container: corev1.Container{
+			Name:         "artifact-copy-to-workspace-9l9zj",
+			Image:        "docker.io/amazon/aws-cli",
+			Command:      []string{"bash"},
+			Args:         []string{"-c", s3PathStyleScript, "aws", "--endpoint-url", "http://minio:9000", "--region", "us-east-1", "s3", "cp", "--recursive", "src-path", "s3://fake-bucket/workspace/destination/"},
+			Env:          []corev1.EnvVar{{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: fmt.Sprintf("/var/bucketsecret/%s/credentials", secretName)}},
+			VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
+		},
+	}}
+
+	got := s3Bucket.GetCopyToStorageFromSteps("workspace", "src-path", "workspace/destination")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3GetSecretsVolumes(t *testing.T) {
+	want := []corev1.Volume{{
+		Name: expectedVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}}
+	got := s3Bucket.GetSecretsVolumes()
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
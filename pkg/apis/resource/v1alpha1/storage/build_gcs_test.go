@@ -37,6 +37,7 @@ var images = pipeline.Images{
 	KubeconfigWriterImage:    "override-with-kubeconfig-writer:latest",
 	ShellImage:               "busybox",
 	GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
+	AwsCLIImage:              "docker.io/amazon/aws-cli",
 	BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 	PRImage:                  "override-with-pr:latest",
 	ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
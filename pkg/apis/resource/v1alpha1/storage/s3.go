@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// S3Resource is an object or a prefix in an S3-compatible object store (e.g. AWS S3,
+// MinIO or Ceph RGW) from which to get artifacts or to which artifacts are uploaded.
+type S3Resource struct {
+	Name           string                                `json:"name"`
+	Type           resourcev1alpha1.PipelineResourceType `json:"type"`
+	Location       string                                `json:"location"`
+	TypeDir        bool                                  `json:"typeDir"`
+	Endpoint       string                                `json:"endpoint"`
+	Region         string                                `json:"region"`
+	ForcePathStyle bool                                  `json:"forcePathStyle"`
+	//Secret holds a struct to indicate a field name and corresponding secret name to populate it
+	Secrets []resourcev1alpha1.SecretParam `json:"secrets"`
+
+	ShellImage  string `json:"-"`
+	AwsCLIImage string `json:"-"`
+}
+
+// NewS3Resource creates a new S3 resource to pass to a Task
+func NewS3Resource(name string, images pipeline.Images, r *resourcev1alpha1.PipelineResource) (*S3Resource, error) {
+	if r.Spec.Type != resourcev1alpha1.PipelineResourceTypeStorage {
+		return nil, fmt.Errorf("S3Resource: Cannot create an S3 resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &S3Resource{
+		Name:        name,
+		Type:        r.Spec.Type,
+		Secrets:     r.Spec.SecretParams,
+		ShellImage:  images.ShellImage,
+		AwsCLIImage: images.AwsCLIImage,
+	}
+
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "Location"):
+			s.Location = param.Value
+		case strings.EqualFold(param.Name, "Dir"):
+			s.TypeDir = true // if dir flag is present then its a dir
+		case strings.EqualFold(param.Name, "Endpoint"):
+			s.Endpoint = param.Value
+		case strings.EqualFold(param.Name, "Region"):
+			s.Region = param.Value
+		case strings.EqualFold(param.Name, "ForcePathStyle"):
+			b, err := strconv.ParseBool(param.Value)
+			if err != nil {
+				return nil, fmt.Errorf("S3Resource: Invalid forcePathStyle param %q of S3 resource %s: %w", param.Value, r.Name, err)
+			}
+			s.ForcePathStyle = b
+		}
+	}
+
+	if s.Location == "" {
+		return nil, fmt.Errorf("S3Resource: Need Location to be specified in order to create S3 resource %s", r.Name)
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s S3Resource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "storage"
+func (s S3Resource) GetType() resourcev1alpha1.PipelineResourceType {
+	return resourcev1alpha1.PipelineResourceTypeStorage
+}
+
+// GetSecretParams returns the resource secret params
+func (s *S3Resource) GetSecretParams() []resourcev1alpha1.SecretParam { return s.Secrets }
+
+// Replacements is used for template replacement on an S3Resource inside of a Taskrun.
+func (s *S3Resource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     s.Type,
+		"location": s.Location,
+		"endpoint": s.Endpoint,
+		"region":   s.Region,
+	}
+}
+
+// GetOutputTaskModifier returns the TaskModifier to be used when this resource is an output.
+func (s *S3Resource) GetOutputTaskModifier(ts *v1beta1.TaskSpec, path string) (v1beta1.TaskModifier, error) {
+	var args []string
+	if s.TypeDir {
+		args = []string{"sync", "--delete", path, s.Location}
+	} else {
+		args = []string{"cp", "--recursive", path, s.Location}
+	}
+
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts(s.Name, gcsSecretVolumeMountPath, s.Secrets)
+
+	command, args := s3Command(s.Endpoint, s.Region, s.ForcePathStyle, args...)
+	step := v1beta1.Step{
+		Container: corev1.Container{
+			Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("upload-%s", s.Name)),
+			Image:        s.AwsCLIImage,
+			Command:      command,
+			Args:         args,
+			VolumeMounts: secretVolumeMount,
+			Env:          envVars,
+		},
+	}
+
+	volumes := getStorageVolumeSpec(s, *ts)
+
+	return &v1beta1.InternalTaskModifier{
+		StepsToAppend: []v1beta1.Step{step},
+		Volumes:       volumes,
+	}, nil
+}
+
+// GetInputTaskModifier returns the TaskModifier to be used when this resource is an input.
+func (s *S3Resource) GetInputTaskModifier(ts *v1beta1.TaskSpec, path string) (v1beta1.TaskModifier, error) {
+	if path == "" {
+		return nil, fmt.Errorf("S3Resource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	var args []string
+	if s.TypeDir {
+		args = []string{"sync", "--delete", s.Location, path}
+	} else {
+		args = []string{"cp", s.Location, path + "/"}
+	}
+
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts(s.Name, gcsSecretVolumeMountPath, s.Secrets)
+	command, args := s3Command(s.Endpoint, s.Region, s.ForcePathStyle, args...)
+	steps := []v1beta1.Step{
+		CreateDirStep(s.ShellImage, s.Name, path),
+		{
+			Container: corev1.Container{
+				Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("fetch-%s", s.Name)),
+				Image:        s.AwsCLIImage,
+				Command:      command,
+				Args:         args,
+				Env:          envVars,
+				VolumeMounts: secretVolumeMount,
+			},
+		},
+	}
+
+	volumes := getStorageVolumeSpec(s, *ts)
+
+	return &v1beta1.InternalTaskModifier{
+		StepsToPrepend: steps,
+		Volumes:        volumes,
+	}, nil
+}
+
+// s3PathStyleScript configures the AWS CLI to use path-style requests and then runs it with the script's
+// arguments, so that none of the values of the resource are interpreted by the shell.
+const s3PathStyleScript = `aws configure set default.s3.addressing_style path && exec aws "$@"`
+
+// s3Command returns the command and the args running `aws s3` with args against the given endpoint and
+// region, configuring the AWS CLI to use path-style requests first when forcePathStyle is set.
+func s3Command(endpoint, region string, forcePathStyle bool, args ...string) ([]string, []string) {
+	var awsArgs []string
+	if endpoint != "" {
+		awsArgs = append(awsArgs, "--endpoint-url", endpoint)
+	}
+	if region != "" {
+		awsArgs = append(awsArgs, "--region", region)
+	}
+	awsArgs = append(awsArgs, "s3")
+	awsArgs = append(awsArgs, args...)
+	if forcePathStyle {
+		// The first argument after the script is its $0.
+		return []string{"bash"}, append([]string{"-c", s3PathStyleScript, "aws"}, awsArgs...)
+	}
+	return []string{"aws"}, awsArgs
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/storage"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInvalidNewS3Resource(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pipelineResource *resourcev1alpha1.PipelineResource
+	}{{
+		name: "no location params",
+		pipelineResource: tb.PipelineResource("s3-resource",
+			tb.PipelineResourceSpec(resourcev1alpha1.PipelineResourceTypeStorage,
+				tb.PipelineResourceSpecParam("NotLocation", "doesntmatter"),
+				tb.PipelineResourceSpecParam("type", "s3"),
+			),
+		),
+	}, {
+		name: "invalid forcePathStyle",
+		pipelineResource: tb.PipelineResource("s3-resource",
+			tb.PipelineResourceSpec(resourcev1alpha1.PipelineResourceTypeStorage,
+				tb.PipelineResourceSpecParam("Location", "s3://fake-bucket"),
+				tb.PipelineResourceSpecParam("type", "s3"),
+				tb.PipelineResourceSpecParam("forcePathStyle", "maybe"),
+			),
+		),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := storage.NewResource("test-resource", images, tc.pipelineResource)
+			if err == nil {
+				t.Error("Expected error creating S3 resource")
+			}
+		})
+	}
+}
+
+func TestValidNewS3Resource(t *testing.T) {
+	pr := tb.PipelineResource("s3-resource", tb.PipelineResourceSpec(
+		resourcev1alpha1.PipelineResourceTypeStorage,
+		tb.PipelineResourceSpecParam("Location", "s3://fake-bucket/path"),
+		tb.PipelineResourceSpecParam("type", "s3"),
+		tb.PipelineResourceSpecParam("dir", "anything"),
+		tb.PipelineResourceSpecParam("endpoint", "http://minio:9000"),
+		tb.PipelineResourceSpecParam("region", "us-east-1"),
+		tb.PipelineResourceSpecParam("forcePathStyle", "true"),
+		tb.PipelineResourceSpecSecretParam("AWS_SHARED_CREDENTIALS_FILE", "secretName", "credentials"),
+	))
+	expectedS3Resource := &storage.S3Resource{
+		Name:           "test-resource",
+		Location:       "s3://fake-bucket/path",
+		Type:           resourcev1alpha1.PipelineResourceTypeStorage,
+		TypeDir:        true,
+		Endpoint:       "http://minio:9000",
+		Region:         "us-east-1",
+		ForcePathStyle: true,
+		Secrets: []resourcev1alpha1.SecretParam{{
+			SecretName: "secretName",
+			SecretKey:  "credentials",
+			FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+		}},
+		ShellImage:  "busybox",
+		AwsCLIImage: "docker.io/amazon/aws-cli",
+	}
+
+	s3Res, err := storage.NewResource("test-resource", images, pr)
+	if err != nil {
+		t.Fatalf("Unexpected error creating S3 resource: %s", err)
+	}
+	if d := cmp.Diff(expectedS3Resource, s3Res); d != "" {
+		t.Errorf("Mismatch of S3 resource %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3GetReplacements(t *testing.T) {
+	s3Resource := &storage.S3Resource{
+		Name:     "s3-resource",
+		Location: "s3://fake-bucket",
+		Type:     resourcev1alpha1.PipelineResourceTypeS3,
+		Endpoint: "http://minio:9000",
+	}
+	expectedReplacementMap := map[string]string{
+		"name":     "s3-resource",
+		"type":     "s3",
+		"location": "s3://fake-bucket",
+		"endpoint": "http://minio:9000",
+		"region":   "",
+	}
+	if d := cmp.Diff(expectedReplacementMap, s3Resource.Replacements()); d != "" {
+		t.Errorf("S3 Replacement map mismatch %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestS3GetInputSteps(t *testing.T) {
+	names.TestingSeed()
+
+	for _, tc := range []struct {
+		name       string
+		s3Resource *storage.S3Resource
+		wantSteps  []v1beta1.Step
+	}{{
+		name: "download directory from an on-premise object store",
+		s3Resource: &storage.S3Resource{
+			Name:           "s3-valid",
+			Location:       "s3://some-bucket/path",
+			TypeDir:        true,
+			Endpoint:       "http://minio:9000",
+			ForcePathStyle: true,
+			Secrets: []resourcev1alpha1.SecretParam{{
+				SecretName: "secretName",
+				FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+				SecretKey:  "credentials",
+			}},
+			ShellImage:  "busybox",
+			AwsCLIImage: "docker.io/amazon/aws-cli",
+		},
+		wantSteps: []v1beta1.Step{{Container: corev1.Container{
+			Name:    "create-dir-s3-valid-9l9zj",
+			Image:   "busybox",
+			Command: []string{"mkdir", "-p", "/workspace"},
+		}}, {
+			Container: corev1.Container{
+				Name:    "fetch-s3-valid-mz4c7",
+				Image:   "docker.io/amazon/aws-cli",
+				Command: []string{"bash"},
+				Args:    []string{"-c", s3PathStyleScript, "aws", "--endpoint-url", "http://minio:9000", "s3", "sync", "--delete", "s3://some-bucket/path", "/workspace"},
+				Env: []corev1.EnvVar{{
+					Name:  "AWS_SHARED_CREDENTIALS_FILE",
+					Value: "/var/secret/secretName/credentials",
+				}},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "volume-s3-valid-secretName",
+					MountPath: "/var/secret/secretName",
+				}},
+			},
+		}},
+	}, {
+		name: "download single object from AWS",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/file.zip",
+			Region:      "eu-west-1",
+			ShellImage:  "busybox",
+			AwsCLIImage: "docker.io/amazon/aws-cli",
+		},
+		wantSteps: []v1beta1.Step{{Container: corev1.Container{
+			Name:    "create-dir-s3-valid-mssqb",
+			Image:   "busybox",
+			Command: []string{"mkdir", "-p", "/workspace"},
+		}}, {
+			Container: corev1.Container{
+				Name:    "fetch-s3-valid-78c5n",
+				Image:   "docker.io/amazon/aws-cli",
+				Command: []string{"aws"},
+				Args:    []string{"--region", "eu-west-1", "s3", "cp", "s3://some-bucket/file.zip", "/workspace/"},
+			},
+		}},
+	}, {
+		name: "values are not interpreted by a shell",
+		s3Resource: &storage.S3Resource{
+			Name:           "s3-valid",
+			Location:       "s3://some-bucket/file.zip; rm -rf /",
+			Region:         "eu-west-1 `id`",
+			ForcePathStyle: true,
+			ShellImage:     "busybox",
+			AwsCLIImage:    "docker.io/amazon/aws-cli",
+		},
+		wantSteps: []v1beta1.Step{{Container: corev1.Container{
+			Name:    "create-dir-s3-valid-6nl7g",
+			Image:   "busybox",
+			Command: []string{"mkdir", "-p", "/workspace"},
+		}}, {
+			Container: corev1.Container{
+				Name:    "fetch-s3-valid-j2tds",
+				Image:   "docker.io/amazon/aws-cli",
+				Command: []string{"bash"},
+				Args:    []string{"-c", s3PathStyleScript, "aws", "--region", "eu-west-1 `id`", "s3", "cp", "s3://some-bucket/file.zip; rm -rf /", "/workspace/"},
+			},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1beta1.TaskSpec{}
+			gotSpec, err := tc.s3Resource.GetInputTaskModifier(&ts, "/workspace")
+			if err != nil {
+				t.Fatalf("Unexpected error getting input steps: %v", err)
+			}
+			if d := cmp.Diff(tc.wantSteps, gotSpec.GetStepsToPrepend()); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestS3GetOutputTaskModifier(t *testing.T) {
+	names.TestingSeed()
+
+	for _, tc := range []struct {
+		name       string
+		s3Resource *storage.S3Resource
+		wantSteps  []v1beta1.Step
+	}{{
+		name: "upload directory to an on-premise object store",
+		s3Resource: &storage.S3Resource{
+			Name:           "s3-valid",
+			Location:       "s3://some-bucket/path",
+			TypeDir:        true,
+			Endpoint:       "http://minio:9000",
+			ForcePathStyle: true,
+			Secrets: []resourcev1alpha1.SecretParam{{
+				SecretName: "secretName",
+				FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+				SecretKey:  "credentials",
+			}},
+			AwsCLIImage: "docker.io/amazon/aws-cli",
+		},
+		wantSteps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:    "upload-s3-valid-9l9zj",
+				Image:   "docker.io/amazon/aws-cli",
+				Command: []string{"bash"},
+				Args:    []string{"-c", s3PathStyleScript, "aws", "--endpoint-url", "http://minio:9000", "s3", "sync", "--delete", "/workspace/", "s3://some-bucket/path"},
+				Env:     []corev1.EnvVar{{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: "/var/secret/secretName/credentials"}},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "volume-s3-valid-secretName",
+					MountPath: "/var/secret/secretName",
+				}},
+			},
+		}},
+	}, {
+		name: "upload files to AWS",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/path",
+			AwsCLIImage: "docker.io/amazon/aws-cli",
+		},
+		wantSteps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:    "upload-s3-valid-mz4c7",
+				Image:   "docker.io/amazon/aws-cli",
+				Command: []string{"aws"},
+				Args:    []string{"s3", "cp", "--recursive", "/workspace/", "s3://some-bucket/path"},
+			},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1beta1.TaskSpec{}
+			got, err := tc.s3Resource.GetOutputTaskModifier(&ts, "/workspace/")
+			if err != nil {
+				t.Fatalf("Unexpected error getting output steps: %v", err)
+			}
+			if d := cmp.Diff(tc.wantSteps, got.GetStepsToAppend()); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// s3PathStyleScript is the script configuring path-style requests before running the AWS CLI with its arguments.
+const s3PathStyleScript = `aws configure set default.s3.addressing_style path && exec aws "$@"`
@@ -35,6 +35,7 @@ func getSecretEnvVarsAndVolumeMounts(name, mountPath string, secrets []resource.
 	allowedFields := map[string]bool{
 		"GOOGLE_APPLICATION_CREDENTIALS": false,
 		"BOTO_CONFIG":                    false,
+		"AWS_SHARED_CREDENTIALS_FILE":    false,
 	}
 
 	for _, secretParam := range secrets {
@@ -48,6 +48,8 @@ func NewResource(name string, images pipeline.Images, r *resource.PipelineResour
 				return NewGCSResource(name, images, r)
 			case strings.EqualFold(param.Value, resource.PipelineResourceTypeBuildGCS):
 				return NewBuildGCSResource(name, images, r)
+			case strings.EqualFold(param.Value, resource.PipelineResourceTypeS3):
+				return NewS3Resource(name, images, r)
 			default:
 				return nil, fmt.Errorf("%s is an invalid or unimplemented PipelineStorageResource", param.Value)
 			}
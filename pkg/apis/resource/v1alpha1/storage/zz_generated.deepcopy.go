@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -46,6 +47,22 @@ func (in *ArtifactBucket) DeepCopy() *ArtifactBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactOCI) DeepCopyInto(out *ArtifactOCI) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactOCI.
+func (in *ArtifactOCI) DeepCopy() *ArtifactOCI {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactOCI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactPVC) DeepCopyInto(out *ArtifactPVC) {
 	*out = *in
@@ -66,3 +83,24 @@ func (in *ArtifactPVC) DeepCopy() *ArtifactPVC {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactS3) DeepCopyInto(out *ArtifactS3) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]v1alpha1.SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactS3.
+func (in *ArtifactS3) DeepCopy() *ArtifactS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactS3)
+	in.DeepCopyInto(out)
+	return out
+}
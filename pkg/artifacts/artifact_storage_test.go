@@ -43,9 +43,11 @@ var (
 		KubeconfigWriterImage:    "override-with-kubeconfig-writer:latest",
 		ShellImage:               "busybox",
 		GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
+		AwsCLIImage:              "docker.io/amazon/aws-cli",
 		BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+		WorkspaceArtifactImage:   "override-with-workspace-artifact-image:latest",
 	}
 	pipelinerun = &v1beta1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -276,6 +278,31 @@ func TestInitializeArtifactStorage(t *testing.T) {
 				SecretName: "secret1",
 			}},
 		},
+	}, {
+		desc: "valid s3 bucket",
+		storageConfig: map[string]string{
+			config.BucketLocationKey:                 "s3://fake-bucket",
+			config.BucketServiceAccountSecretNameKey: "secret1",
+			config.BucketServiceAccountSecretKeyKey:  "sakey",
+			config.BucketServiceAccountFieldNameKey:  "AWS_SHARED_CREDENTIALS_FILE",
+			config.BucketS3EndpointKey:               "http://minio:9000",
+			config.BucketS3RegionKey:                 "us-east-1",
+			config.BucketS3ForcePathStyleKey:         "true",
+		},
+		storagetype: "bucket",
+		expectedArtifactStorage: &storage.ArtifactS3{
+			Location:       "s3://fake-bucket",
+			Endpoint:       "http://minio:9000",
+			Region:         "us-east-1",
+			ForcePathStyle: true,
+			ShellImage:     "busybox",
+			AwsCLIImage:    "docker.io/amazon/aws-cli",
+			Secrets: []resourcev1alpha1.SecretParam{{
+				FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+				SecretKey:  "sakey",
+				SecretName: "secret1",
+			}},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset()
@@ -525,6 +552,18 @@ func TestGetArtifactStorageWithConfig(t *testing.T) {
 			ShellImage:  "busybox",
 			GsutilImage: "gcr.io/google.com/cloudsdktool/cloud-sdk",
 		},
+	}, {
+		desc: "valid s3 bucket",
+		storageConfig: map[string]string{
+			config.BucketLocationKey:   "s3://fake-bucket",
+			config.BucketS3EndpointKey: "http://minio:9000",
+		},
+		expectedArtifactStorage: &storage.ArtifactS3{
+			Location:    "s3://fake-bucket",
+			Endpoint:    "http://minio:9000",
+			ShellImage:  "busybox",
+			AwsCLIImage: "docker.io/amazon/aws-cli",
+		},
 	}, {
 		desc: "location empty",
 		storageConfig: map[string]string{
@@ -112,7 +112,7 @@ func InitializeArtifactStorage(ctx context.Context, images pipeline.Images, pr *
 		return &storage.ArtifactPVC{Name: pr.Name, PersistentVolumeClaim: pvc, ShellImage: images.ShellImage}, nil
 	}
 
-	return NewArtifactBucketFromConfig(ctx, images), nil
+	return newArtifactStorageFromConfig(ctx, images), nil
 }
 
 // CleanupArtifactStorage will delete the PipelineRun's artifact storage PVC if it exists. The PVC is created for using
@@ -148,6 +148,15 @@ func GetArtifactStorage(ctx context.Context, images pipeline.Images, prName stri
 	if NeedsPVC(ctx) {
 		return &storage.ArtifactPVC{Name: prName, ShellImage: images.ShellImage}
 	}
+	return newArtifactStorageFromConfig(ctx, images)
+}
+
+// newArtifactStorageFromConfig returns the S3-compatible bucket or the GCS bucket
+// configured in the ConfigMap, depending on the scheme of its location.
+func newArtifactStorageFromConfig(ctx context.Context, images pipeline.Images) ArtifactStorageInterface {
+	if config.FromContextOrDefaults(ctx).ArtifactBucket.IsS3() {
+		return NewArtifactS3FromConfig(ctx, images)
+	}
 	return NewArtifactBucketFromConfig(ctx, images)
 }
 
@@ -170,6 +179,27 @@ func NewArtifactBucketFromConfig(ctx context.Context, images pipeline.Images) *s
 	return c
 }
 
+// NewArtifactS3FromConfig creates an S3-compatible Bucket from the supplied ConfigMap
+func NewArtifactS3FromConfig(ctx context.Context, images pipeline.Images) *storage.ArtifactS3 {
+	bucketConfig := config.FromContextOrDefaults(ctx).ArtifactBucket
+	c := &storage.ArtifactS3{
+		Location:       bucketConfig.Location,
+		Endpoint:       bucketConfig.S3Endpoint,
+		Region:         bucketConfig.S3Region,
+		ForcePathStyle: bucketConfig.S3ForcePathStyle,
+		ShellImage:     images.ShellImage,
+		AwsCLIImage:    images.AwsCLIImage,
+	}
+	if bucketConfig.ServiceAccountSecretName != "" && bucketConfig.ServiceAccountSecretKey != "" {
+		c.Secrets = append(c.Secrets, resourcev1alpha1.SecretParam{
+			SecretName: bucketConfig.ServiceAccountSecretName,
+			SecretKey:  bucketConfig.ServiceAccountSecretKey,
+			FieldName:  bucketConfig.ServiceAccountFieldName,
+		})
+	}
+	return c
+}
+
 func createPVC(ctx context.Context, pr *v1beta1.PipelineRun, c kubernetes.Interface) (*corev1.PersistentVolumeClaim, error) {
 	if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(ctx, GetPVCName(pr), metav1.GetOptions{}); err != nil {
 		if errors.IsNotFound(err) {
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+)
+
+func TestPushPull(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := fmt.Sprintf("%s/workspaces:run-build", u.Host)
+
+	src, err := ioutil.TempDir("", "push")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	if err := os.MkdirAll(filepath.Join(src, "sub", "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "file"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "sub", "dir", "script.sh"), []byte("echo hi"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Push(src, ref); err != nil {
+		t.Fatalf("unexpected error pushing: %v", err)
+	}
+
+	dst, err := ioutil.TempDir("", "pull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+	found, err := Pull(dst, ref)
+	if err != nil {
+		t.Fatalf("unexpected error pulling: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected %s to be found", ref)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dst, "file"))
+	if err != nil || string(b) != "hello" {
+		t.Errorf("expected file to contain hello, got %q, %v", b, err)
+	}
+	info, err := os.Stat(filepath.Join(dst, "sub", "dir", "script.sh"))
+	if err != nil {
+		t.Fatalf("expected script.sh to be extracted: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("expected script.sh to keep its mode, got %v", info.Mode().Perm())
+	}
+}
+
+func TestPullNotFound(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := Pull(os.TempDir(), fmt.Sprintf("%s/workspaces:skipped", u.Host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found {
+		t.Errorf("expected the artifact not to be found")
+	}
+}
+
+func TestExtractTarRejectsPathTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte("evil")
+	if err := tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := ioutil.TempDir("", "extract")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+	if err := extractTar(&buf, dst); err == nil {
+		t.Errorf("expected an error extracting a path outside of the directory")
+	}
+}
+
+func TestExtractTarRejectsSymlinkEscape(t *testing.T) {
+	outside, err := ioutil.TempDir("", "outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "a", Linkname: outside, Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("evil")
+	if err := tw.WriteHeader(&tar.Header{Name: "a/entrypoint", Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := ioutil.TempDir("", "extract")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+	if err := extractTar(&buf, dst); err == nil {
+		t.Errorf("expected an error extracting a path through a symlink outside of the directory")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "entrypoint")); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be written outside of the directory, got %v", err)
+	}
+}
@@ -18,10 +18,12 @@ package entrypoint
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -35,6 +37,11 @@ const (
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
+// ErrInactivityTimeout is returned by a Runner when it stopped the command
+// because the command didn't write any output for longer than the Step's
+// inactivity timeout.
+var ErrInactivityTimeout = errors.New("step produced no output within the inactivity timeout")
+
 // Entrypointer holds fields for running commands with redirected
 // entrypoints.
 type Entrypointer struct {
@@ -66,6 +73,19 @@ type Entrypointer struct {
 	Results []string
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
+	// Retries is the number of times to retry the command if it fails.
+	Retries int
+	// RetryDelay is how long to wait before each retry of the command.
+	RetryDelay time.Duration
+	// ProgressFile is the file the command may write its progress to. If
+	// specified, the last progress written is reported when complete.
+	ProgressFile string
+	// ProgressPublisher, if specified, publishes the progress written to
+	// the ProgressFile every ProgressInterval while the command is running.
+	ProgressPublisher ProgressPublisher
+	// ProgressInterval is how often the ProgressFile is read while the
+	// command is running.
+	ProgressInterval time.Duration
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -79,6 +99,36 @@ type Runner interface {
 	Run(ctx context.Context, args ...string) error
 }
 
+// ResourceUsage is the resources used by a command.
+type ResourceUsage struct {
+	// UserCPUTime is the CPU time spent in user mode.
+	UserCPUTime time.Duration
+	// SystemCPUTime is the CPU time spent in kernel mode.
+	SystemCPUTime time.Duration
+	// MaxRSS is the peak resident set size, in bytes.
+	MaxRSS int64
+	// FilesystemReads is the number of filesystem input operations.
+	FilesystemReads int64
+	// FilesystemWrites is the number of filesystem output operations.
+	FilesystemWrites int64
+}
+
+// ResourceUsageReporter is optionally implemented by Runners that can report
+// the resources used by the commands they run.
+type ResourceUsageReporter interface {
+	// ResourceUsage returns the resources used by all of the commands run so
+	// far, or nil if they are not known.
+	ResourceUsage() *ResourceUsage
+}
+
+// OutputTailReporter is optionally implemented by Runners that keep the last
+// lines of output written by the commands they run.
+type OutputTailReporter interface {
+	// OutputTail returns the last lines written to stdout and stderr by the
+	// last command run.
+	OutputTail() string
+}
+
 // PostWriter encapsulates writing a file when complete.
 type PostWriter interface {
 	// Write writes to the path when complete.
@@ -93,7 +143,17 @@ func (e Entrypointer) Go() error {
 
 	output := []v1beta1.PipelineResourceResult{}
 	defer func() {
-		if wErr := termination.WriteMessage(e.TerminationPath, output); wErr != nil {
+		wErr := termination.WriteMessage(e.TerminationPath, output)
+		// The Step's metadata is only a convenience, so drop it rather than
+		// fail if there isn't room for it alongside the Step's results.
+		for _, keys := range optionalResultKeys {
+			if _, ok := wErr.(termination.MessageLengthError); !ok {
+				break
+			}
+			output = withoutKeys(output, keys...)
+			wErr = termination.WriteMessage(e.TerminationPath, output)
+		}
+		if wErr != nil {
 			logger.Fatalf("Error while writing message: %s", wErr)
 		}
 		_ = logger.Sync()
@@ -104,9 +164,18 @@ func (e Entrypointer) Go() error {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
 			e.WritePostFile(e.PostFile, err)
+			now := time.Now().Format(timeFormat)
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "StartedAt",
-				Value:      time.Now().Format(timeFormat),
+				Value:      now,
+				ResultType: v1beta1.InternalTektonResultType,
+			}, v1beta1.PipelineResourceResult{
+				Key:        "CompletedAt",
+				Value:      now,
+				ResultType: v1beta1.InternalTektonResultType,
+			}, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      "Skipped",
 				ResultType: v1beta1.InternalTektonResultType,
 			})
 			return err
@@ -117,6 +186,12 @@ func (e Entrypointer) Go() error {
 		e.Args = append([]string{e.Entrypoint}, e.Args...)
 	}
 
+	if e.ProgressFile != "" {
+		if err := createProgressDir(e.ProgressFile); err != nil {
+			logger.Warnf("Error creating the directory for the progress file: %v", err)
+		}
+	}
+
 	output = append(output, v1beta1.PipelineResourceResult{
 		Key:        "StartedAt",
 		Value:      time.Now().Format(timeFormat),
@@ -129,22 +204,77 @@ func (e Entrypointer) Go() error {
 	}
 
 	if err == nil {
-		ctx := context.Background()
-		var cancel context.CancelFunc
-		if e.Timeout != nil && *e.Timeout != time.Duration(0) {
-			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
-			defer cancel()
+		stopProgress := e.publishProgress(logger)
+		attempts := 1
+		for ; ; attempts++ {
+			err = e.run()
+			if err == nil || attempts > e.Retries {
+				break
+			}
+			logger.Infof("Attempt %d of %d failed: %v", attempts, e.Retries+1, err)
+			time.Sleep(e.RetryDelay)
 		}
-		err = e.Runner.Run(ctx, e.Args...)
-		if err == context.DeadlineExceeded {
+		stopProgress()
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "Attempts",
+			Value:      strconv.Itoa(attempts),
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		if r, ok := e.Runner.(ResourceUsageReporter); ok {
+			if usage := r.ResourceUsage(); usage != nil {
+				output = append(output, resourceUsageResults(*usage)...)
+			}
+		}
+		if r, ok := e.Runner.(OutputTailReporter); ok && err != nil {
+			if tail := r.OutputTail(); tail != "" {
+				output = append(output, v1beta1.PipelineResourceResult{
+					Key:        "OutputTail",
+					Value:      tail,
+					ResultType: v1beta1.InternalTektonResultType,
+				})
+			}
+		}
+		if exitCode, ok := exitCodeFromError(err); ok {
 			output = append(output, v1beta1.PipelineResourceResult{
-				Key:        "Reason",
-				Value:      "TimeoutExceeded",
+				Key:        "ExitCode",
+				Value:      strconv.Itoa(exitCode),
 				ResultType: v1beta1.InternalTektonResultType,
 			})
 		}
 	}
 
+	if e.ProgressFile != "" {
+		progress, pErr := ReadProgress(e.ProgressFile)
+		if pErr != nil {
+			logger.Warnf("Error reading the progress file: %v", pErr)
+		} else if progress != "" {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Progress",
+				Value:      progress,
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
+	}
+
+	reason := "Completed"
+	switch {
+	case err == context.DeadlineExceeded:
+		reason = "TimeoutExceeded"
+	case err == ErrInactivityTimeout:
+		reason = "InactivityTimeoutExceeded"
+	case err != nil:
+		reason = "Error"
+	}
+	output = append(output, v1beta1.PipelineResourceResult{
+		Key:        "CompletedAt",
+		Value:      time.Now().Format(timeFormat),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, v1beta1.PipelineResourceResult{
+		Key:        "Reason",
+		Value:      reason,
+		ResultType: v1beta1.InternalTektonResultType,
+	})
+
 	// Write the post file *no matter what*
 	e.WritePostFile(e.PostFile, err)
 
@@ -159,6 +289,17 @@ func (e Entrypointer) Go() error {
 	return err
 }
 
+// run runs the command once, within the Step's timeout if one was specified.
+func (e Entrypointer) run() error {
+	ctx := context.Background()
+	if e.Timeout != nil && *e.Timeout != time.Duration(0) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
+		defer cancel()
+	}
+	return e.Runner.Run(ctx, e.Args...)
+}
+
 func (e Entrypointer) readResultsFromDisk() error {
 	output := []v1beta1.PipelineResourceResult{}
 	for _, resultFile := range e.Results {
@@ -187,6 +328,75 @@ func (e Entrypointer) readResultsFromDisk() error {
 	return nil
 }
 
+// resourceUsageResults returns the termination message entries reporting usage.
+func resourceUsageResults(usage ResourceUsage) []v1beta1.PipelineResourceResult {
+	return []v1beta1.PipelineResourceResult{{
+		Key:        "UserCPUTime",
+		Value:      usage.UserCPUTime.String(),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "SystemCPUTime",
+		Value:      usage.SystemCPUTime.String(),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "MaxRSS",
+		Value:      strconv.FormatInt(usage.MaxRSS, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "FilesystemReads",
+		Value:      strconv.FormatInt(usage.FilesystemReads, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "FilesystemWrites",
+		Value:      strconv.FormatInt(usage.FilesystemWrites, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}}
+}
+
+// optionalResultKeys are the keys of the internal termination message entries
+// that are dropped, in this order, when there isn't room for them alongside the
+// Step's results. StartedAt is always kept.
+var optionalResultKeys = [][]string{
+	{"OutputTail"},
+	{"UserCPUTime", "SystemCPUTime", "MaxRSS", "FilesystemReads", "FilesystemWrites", "Attempts", "Progress"},
+	{"ExitCode", "CompletedAt", "Reason"},
+}
+
+// withoutKeys returns the termination message entries without the internal
+// entries with the given keys.
+func withoutKeys(output []v1beta1.PipelineResourceResult, keys ...string) []v1beta1.PipelineResourceResult {
+	var trimmed []v1beta1.PipelineResourceResult
+	for _, r := range output {
+		if r.ResultType == v1beta1.InternalTektonResultType && contains(keys, r.Key) {
+			continue
+		}
+		trimmed = append(trimmed, r)
+	}
+	return trimmed
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// exitCodeFromError returns the exit code of the command that returned err, and
+// whether it could be determined. A nil err means the command exited with 0.
+func exitCodeFromError(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), true
+	}
+	return 0, false
+}
+
 // WritePostFile write the postfile
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
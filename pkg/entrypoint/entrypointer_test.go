@@ -20,15 +20,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
 )
 
 func TestEntrypointerFailures(t *testing.T) {
@@ -38,41 +42,48 @@ func TestEntrypointerFailures(t *testing.T) {
 		waiter         Waiter
 		runner         Runner
 		expectedError  string
+		expectedReason string
 		timeout        time.Duration
 	}{{
-		desc:          "failing runner with postFile",
-		runner:        &fakeErrorRunner{},
-		expectedError: "runner failed",
-		postFile:      "foo",
-		timeout:       time.Duration(0),
+		desc:           "failing runner with postFile",
+		runner:         &fakeErrorRunner{},
+		expectedError:  "runner failed",
+		postFile:       "foo",
+		timeout:        time.Duration(0),
+		expectedReason: "Error",
 	}, {
-		desc:          "failing waiter with no postFile",
-		waitFiles:     []string{"foo"},
-		waiter:        &fakeErrorWaiter{},
-		expectedError: "waiter failed",
-		timeout:       time.Duration(0),
+		desc:           "failing waiter with no postFile",
+		waitFiles:      []string{"foo"},
+		waiter:         &fakeErrorWaiter{},
+		expectedError:  "waiter failed",
+		timeout:        time.Duration(0),
+		expectedReason: "Skipped",
 	}, {
-		desc:          "failing waiter with postFile",
-		waitFiles:     []string{"foo"},
-		waiter:        &fakeErrorWaiter{},
-		expectedError: "waiter failed",
-		postFile:      "bar",
-		timeout:       time.Duration(0),
+		desc:           "failing waiter with postFile",
+		waitFiles:      []string{"foo"},
+		waiter:         &fakeErrorWaiter{},
+		expectedError:  "waiter failed",
+		postFile:       "bar",
+		timeout:        time.Duration(0),
+		expectedReason: "Skipped",
 	}, {
-		desc:          "negative timeout",
-		runner:        &fakeErrorRunner{},
-		timeout:       -10 * time.Second,
-		expectedError: `negative timeout specified`,
+		desc:           "negative timeout",
+		runner:         &fakeErrorRunner{},
+		timeout:        -10 * time.Second,
+		expectedError:  `negative timeout specified`,
+		expectedReason: "Error",
 	}, {
-		desc:          "zero timeout string does not time out",
-		runner:        &fakeZeroTimeoutRunner{},
-		timeout:       time.Duration(0),
-		expectedError: `runner failed`,
+		desc:           "zero timeout string does not time out",
+		runner:         &fakeZeroTimeoutRunner{},
+		timeout:        time.Duration(0),
+		expectedError:  `runner failed`,
+		expectedReason: "Error",
 	}, {
-		desc:          "timeout leads to runner",
-		runner:        &fakeTimeoutRunner{},
-		timeout:       1 * time.Millisecond,
-		expectedError: `runner failed`,
+		desc:           "timeout leads to runner",
+		runner:         &fakeTimeoutRunner{},
+		timeout:        1 * time.Millisecond,
+		expectedError:  `runner failed`,
+		expectedReason: "Error",
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fw := c.waiter
@@ -112,6 +123,27 @@ func TestEntrypointerFailures(t *testing.T) {
 			if c.postFile == "" && fpw.wrote != nil {
 				t.Errorf("Wrote post file when not required")
 			}
+
+			fileContents, err := ioutil.ReadFile("termination")
+			if err != nil {
+				t.Fatalf("Wanted termination file written, got %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Could not parse termination file: %v", err)
+			}
+			var reason string
+			for _, result := range entries {
+				if result.Key == "Reason" {
+					reason = result.Value
+				}
+			}
+			if reason != c.expectedReason {
+				t.Errorf("Got reason %q, want %q", reason, c.expectedReason)
+			}
+			if err := os.Remove("termination"); err != nil {
+				t.Errorf("Could not remove termination path: %s", err)
+			}
 		})
 	}
 }
@@ -204,16 +236,22 @@ func TestEntrypointer(t *testing.T) {
 			if err == nil {
 				var entries []v1alpha1.PipelineResourceResult
 				if err := json.Unmarshal([]byte(fileContents), &entries); err == nil {
-					var found = false
+					found := map[string]string{}
 					for _, result := range entries {
-						if result.Key == "StartedAt" {
-							found = true
-							break
-						}
+						found[result.Key] = result.Value
 					}
-					if !found {
+					if _, ok := found["StartedAt"]; !ok {
 						t.Error("Didn't find the startedAt entry")
 					}
+					if _, ok := found["CompletedAt"]; !ok {
+						t.Error("Didn't find the completedAt entry")
+					}
+					if found["ExitCode"] != "0" {
+						t.Errorf("Got exit code %q, want %q", found["ExitCode"], "0")
+					}
+					if found["Reason"] != "Completed" {
+						t.Errorf("Got reason %q, want %q", found["Reason"], "Completed")
+					}
 				}
 			} else if !os.IsNotExist(err) {
 				t.Error("Wanted termination file written, got nil")
@@ -225,6 +263,306 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointerRetries(t *testing.T) {
+	for _, c := range []struct {
+		desc             string
+		retries          int
+		failures         int
+		expectedRuns     int
+		expectedAttempts string
+		expectError      bool
+	}{{
+		desc:             "succeeds without retries",
+		expectedRuns:     1,
+		expectedAttempts: "1",
+	}, {
+		desc:             "succeeds after retrying",
+		retries:          3,
+		failures:         2,
+		expectedRuns:     3,
+		expectedAttempts: "3",
+	}, {
+		desc:             "fails after running out of retries",
+		retries:          2,
+		failures:         5,
+		expectedRuns:     3,
+		expectedAttempts: "3",
+		expectError:      true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fr := &fakeFlakyRunner{failures: c.failures}
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint:      "echo",
+				Args:            []string{"some", "args"},
+				PostFile:        "writeme",
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: "termination",
+				Retries:         c.retries,
+				RetryDelay:      time.Millisecond,
+			}.Go()
+			if c.expectError && err == nil {
+				t.Error("Expected Entrypointer to fail")
+			}
+			if !c.expectError && err != nil {
+				t.Errorf("Entrypointer failed: %v", err)
+			}
+			if fr.runs != c.expectedRuns {
+				t.Errorf("Ran command %d times, want %d", fr.runs, c.expectedRuns)
+			}
+			wantPostFile := "writeme"
+			if c.expectError {
+				wantPostFile = "writeme.err"
+			}
+			if fpw.wrote == nil || *fpw.wrote != wantPostFile {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, wantPostFile)
+			}
+
+			fileContents, err := ioutil.ReadFile("termination")
+			if err != nil {
+				t.Fatalf("Wanted termination file written, got %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Could not parse termination file: %v", err)
+			}
+			var attempts string
+			for _, result := range entries {
+				if result.Key == "Attempts" {
+					attempts = result.Value
+				}
+			}
+			if attempts != c.expectedAttempts {
+				t.Errorf("Got attempts %q, want %q", attempts, c.expectedAttempts)
+			}
+			if err := os.Remove("termination"); err != nil {
+				t.Errorf("Could not remove termination path: %s", err)
+			}
+		})
+	}
+}
+
+func TestEntrypointerOutputTail(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		runner   *fakeTailRunner
+		wantTail string
+	}{{
+		desc:   "no tail on success",
+		runner: &fakeTailRunner{tail: "all good\n"},
+	}, {
+		desc:     "tail on failure",
+		runner:   &fakeTailRunner{err: errors.New("runner failed"), tail: "error: boom\n"},
+		wantTail: "error: boom\n",
+	}, {
+		desc:   "tail dropped if the termination message would be too long",
+		runner: &fakeTailRunner{err: errors.New("runner failed"), tail: strings.Repeat("x", 4096)},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			_ = Entrypointer{
+				Entrypoint:      "echo",
+				Waiter:          &fakeWaiter{},
+				Runner:          c.runner,
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: "termination",
+			}.Go()
+
+			fileContents, err := ioutil.ReadFile("termination")
+			if err != nil {
+				t.Fatalf("Wanted termination file written, got %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Could not parse termination file: %v", err)
+			}
+			var tail, reason string
+			for _, result := range entries {
+				switch result.Key {
+				case "OutputTail":
+					tail = result.Value
+				case "Reason":
+					reason = result.Value
+				}
+			}
+			if tail != c.wantTail {
+				t.Errorf("Got output tail %q, want %q", tail, c.wantTail)
+			}
+			if reason == "" {
+				t.Error("Expected the termination reason to be written")
+			}
+			if err := os.Remove("termination"); err != nil {
+				t.Errorf("Could not remove termination path: %s", err)
+			}
+		})
+	}
+}
+
+func TestEntrypointerLargeResults(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		size     int
+		wantKeys []string
+	}{{
+		desc:     "small results keep all the metadata",
+		size:     1000,
+		wantKeys: []string{"result", "StartedAt", "Attempts", "UserCPUTime", "SystemCPUTime", "MaxRSS", "FilesystemReads", "FilesystemWrites", "OutputTail", "ExitCode", "CompletedAt", "Reason"},
+	}, {
+		desc:     "results close to the max drop the optional metadata",
+		size:     3700,
+		wantKeys: []string{"result", "StartedAt", "ExitCode", "CompletedAt", "Reason"},
+	}, {
+		desc:     "results at the max only keep the start time",
+		size:     3950,
+		wantKeys: []string{"result", "StartedAt"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			// The results are written to the termination message before the Step's metadata.
+			results, err := json.Marshal([]v1alpha1.PipelineResourceResult{{
+				Key:        "result",
+				Value:      strings.Repeat("x", c.size),
+				ResultType: v1alpha1.TaskRunResultType,
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile("termination", results, 0666); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove("termination")
+
+			_ = Entrypointer{
+				Entrypoint:      "echo",
+				Waiter:          &fakeWaiter{},
+				Runner:          &fakeUsageRunner{fakeTailRunner{err: &fakeExitError{code: 1}, tail: strings.Repeat("y", 100)}},
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: "termination",
+			}.Go()
+
+			fileContents, err := ioutil.ReadFile("termination")
+			if err != nil {
+				t.Fatalf("Wanted termination file written, got %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Could not parse termination file: %v", err)
+			}
+			var keys []string
+			for _, result := range entries {
+				keys = append(keys, result.Key)
+				if result.Key == "result" && len(result.Value) != c.size {
+					t.Errorf("Got a result of %d bytes, want %d", len(result.Value), c.size)
+				}
+			}
+			if d := cmp.Diff(c.wantKeys, keys); d != "" {
+				t.Errorf("Unexpected termination message keys %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointerProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	progressFile := filepath.Join(dir, "0", "progress")
+
+	err = Entrypointer{
+		Entrypoint:      "echo",
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeProgressRunner{progressFile: progressFile, progress: "suite 1/4\nsuite 4/4\n"},
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: "termination",
+		ProgressFile:    progressFile,
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	defer os.Remove("termination")
+
+	fileContents, err := ioutil.ReadFile("termination")
+	if err != nil {
+		t.Fatalf("Wanted termination file written, got %v", err)
+	}
+	var entries []v1alpha1.PipelineResourceResult
+	if err := json.Unmarshal(fileContents, &entries); err != nil {
+		t.Fatalf("Could not parse termination file: %v", err)
+	}
+	var progress string
+	for _, result := range entries {
+		if result.Key == "Progress" {
+			progress = result.Value
+		}
+	}
+	if progress != "suite 4/4" {
+		t.Errorf("Got progress %q, want %q", progress, "suite 4/4")
+	}
+}
+
+func TestEntrypointerPublishesProgressWhileRunning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	progressFile := filepath.Join(dir, "0", "progress")
+	publisher := &fakeProgressPublisher{published: make(chan string, 10)}
+
+	err = Entrypointer{
+		Entrypoint:        "echo",
+		Waiter:            &fakeWaiter{},
+		Runner:            &fakeRunningProgressRunner{progressFile: progressFile, published: publisher.published},
+		PostWriter:        &fakePostWriter{},
+		TerminationPath:   "termination",
+		ProgressFile:      progressFile,
+		ProgressPublisher: publisher,
+		ProgressInterval:  10 * time.Millisecond,
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	defer os.Remove("termination")
+}
+
+func TestEntrypointerStopsPublishingProgressWhenRefused(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	progressFile := filepath.Join(dir, "0", "progress")
+	if err := os.MkdirAll(filepath.Dir(progressFile), 0777); err != nil {
+		t.Fatal(err)
+	}
+	publisher := &fakeProgressPublisher{published: make(chan string, 10), err: fmt.Errorf("forbidden: %w", ErrProgressRefused)}
+
+	e := Entrypointer{
+		ProgressFile:      progressFile,
+		ProgressPublisher: publisher,
+		ProgressInterval:  10 * time.Millisecond,
+	}
+	stop := e.publishProgress(zap.NewNop().Sugar())
+	for _, progress := range []string{"1/2", "2/2"} {
+		if err := ioutil.WriteFile(progressFile, []byte(progress), 0666); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	stop()
+	close(publisher.published)
+
+	var got []string
+	for p := range publisher.published {
+		got = append(got, p)
+	}
+	if d := cmp.Diff([]string{"1/2"}, got); d != "" {
+		t.Errorf("Unexpected progress published %s", diff.PrintWantGot(d))
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool) error {
@@ -276,3 +614,85 @@ func (f *fakeTimeoutRunner) Run(ctx context.Context, args ...string) error {
 	}
 	return errors.New("runner failed")
 }
+
+type fakeFlakyRunner struct {
+	failures int
+	runs     int
+}
+
+func (f *fakeFlakyRunner) Run(ctx context.Context, args ...string) error {
+	f.runs++
+	if f.runs <= f.failures {
+		return errors.New("runner failed")
+	}
+	return nil
+}
+
+type fakeTailRunner struct {
+	err  error
+	tail string
+}
+
+func (f *fakeTailRunner) Run(ctx context.Context, args ...string) error { return f.err }
+
+func (f *fakeTailRunner) OutputTail() string { return f.tail }
+
+// fakeProgressRunner writes progress to the progress file, which must be
+// writable by the time the command runs.
+type fakeProgressRunner struct {
+	progressFile string
+	progress     string
+}
+
+func (f *fakeProgressRunner) Run(ctx context.Context, args ...string) error {
+	return ioutil.WriteFile(f.progressFile, []byte(f.progress), 0666)
+}
+
+// fakeRunningProgressRunner writes progress to the progress file and waits
+// for it to be published before completing.
+type fakeRunningProgressRunner struct {
+	progressFile string
+	published    chan string
+}
+
+func (f *fakeRunningProgressRunner) Run(ctx context.Context, args ...string) error {
+	if err := ioutil.WriteFile(f.progressFile, []byte("suite 1/4\n"), 0666); err != nil {
+		return err
+	}
+	select {
+	case p := <-f.published:
+		if p != "suite 1/4" {
+			return fmt.Errorf("published progress %q, want %q", p, "suite 1/4")
+		}
+		return nil
+	case <-time.After(5 * time.Second):
+		return errors.New("progress wasn't published while running")
+	}
+}
+
+// fakeProgressPublisher sends the progress it publishes to published, then
+// returns err.
+type fakeProgressPublisher struct {
+	published chan string
+	err       error
+}
+
+func (f *fakeProgressPublisher) Publish(progress string) error {
+	f.published <- progress
+	return f.err
+}
+
+// fakeUsageRunner is a fakeTailRunner that also reports resource usage.
+type fakeUsageRunner struct {
+	fakeTailRunner
+}
+
+func (f *fakeUsageRunner) ResourceUsage() *ResourceUsage {
+	return &ResourceUsage{UserCPUTime: time.Second, SystemCPUTime: time.Second, MaxRSS: 1 << 20}
+}
+
+type fakeExitError struct{ code int }
+
+func (f *fakeExitError) Error() string { return "exit status" }
+
+func (f *fakeExitError) ExitCode() int { return f.code }
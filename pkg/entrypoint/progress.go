@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// MaxProgressLength is the maximum length of the progress reported for a
+// Step. Longer progress lines are truncated.
+const MaxProgressLength = 256
+
+// ErrProgressRefused is returned by a ProgressPublisher that won't be able to
+// publish the progress anymore, e.g. because it isn't allowed to.
+var ErrProgressRefused = errors.New("publishing the progress was refused")
+
+// ProgressPublisher publishes the progress of a Step while it's running.
+type ProgressPublisher interface {
+	Publish(progress string) error
+}
+
+// ReadProgress returns the progress a Step wrote to the file at path: the last
+// non-empty line of the file, truncated to MaxProgressLength. It returns an
+// empty string if the file doesn't exist.
+func ReadProgress(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	progress := strings.TrimSpace(lines[len(lines)-1])
+	if len(progress) > MaxProgressLength {
+		progress = progress[:MaxProgressLength]
+		for len(progress) > 0 && !utf8.ValidString(progress) {
+			progress = progress[:len(progress)-1]
+		}
+	}
+	return progress, nil
+}
+
+// createProgressDir creates the directory the Step writes its progress file
+// to. It is world-writable, since the Step may run as any user.
+func createProgressDir(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	// MkdirAll is subject to the umask, so set the permissions explicitly.
+	return os.Chmod(dir, 0777)
+}
+
+// publishProgress reads the progress file every ProgressInterval and publishes
+// the progress whenever it changes, until the returned function is called.
+// Failing to publish isn't fatal, since the progress is reported when the Step
+// completes anyway.
+func (e Entrypointer) publishProgress(logger *zap.SugaredLogger) func() {
+	if e.ProgressFile == "" || e.ProgressPublisher == nil || e.ProgressInterval <= 0 {
+		return func() {}
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(e.ProgressInterval)
+		defer ticker.Stop()
+		var last string
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			progress, err := ReadProgress(e.ProgressFile)
+			if err != nil {
+				logger.Warnf("Error reading the progress file: %v", err)
+				continue
+			}
+			if progress == "" || progress == last {
+				continue
+			}
+			if err := e.ProgressPublisher.Publish(progress); err != nil {
+				logger.Warnf("Error publishing the progress: %v", err)
+				if errors.Is(err, ErrProgressRefused) {
+					return
+				}
+				continue
+			}
+			last = progress
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadProgress(t *testing.T) {
+	for _, c := range []struct {
+		desc    string
+		content *string
+		want    string
+	}{{
+		desc: "no progress file",
+		want: "",
+	}, {
+		desc:    "percentage",
+		content: strPtr("42%\n"),
+		want:    "42%",
+	}, {
+		desc:    "last line wins",
+		content: strPtr("suite 1/4\nsuite 2/4\n\n"),
+		want:    "suite 2/4",
+	}, {
+		desc:    "long progress is truncated",
+		content: strPtr(strings.Repeat("x", MaxProgressLength+10)),
+		want:    strings.Repeat("x", MaxProgressLength),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "progress")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "progress")
+			if c.content != nil {
+				if err := ioutil.WriteFile(path, []byte(*c.content), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := ReadProgress(path)
+			if err != nil {
+				t.Fatalf("ReadProgress: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("ReadProgress() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
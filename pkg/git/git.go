@@ -89,7 +89,24 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		return err
 	}
 	trimmedURL := strings.TrimSpace(spec.URL)
-	if _, err := run(logger, "", "remote", "add", "origin", trimmedURL); err != nil {
+	// The path may already hold a checkout of the repository, e.g. when it
+	// is on a volume reused from a previous run, in which case its origin is
+	// updated and the files the previous run left behind are removed.
+	remotes, err := run(logger, "", "remote")
+	if err != nil {
+		return err
+	}
+	existing := false
+	for _, r := range strings.Fields(remotes) {
+		if r == "origin" {
+			existing = true
+		}
+	}
+	if existing {
+		if _, err := run(logger, "", "remote", "set-url", "origin", trimmedURL); err != nil {
+			return err
+		}
+	} else if _, err := run(logger, "", "remote", "add", "origin", trimmedURL); err != nil {
 		return err
 	}
 
@@ -153,6 +170,11 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	if _, err := run(logger, "", "checkout", "-f", checkoutParam); err != nil {
 		return err
 	}
+	if existing {
+		if _, err := run(logger, "", "clean", "-ffdx"); err != nil {
+			return err
+		}
+	}
 
 	commit, err := ShowCommit(logger, "HEAD", spec.Path)
 	if err != nil {
@@ -18,6 +18,7 @@ package git
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -220,6 +221,48 @@ func TestFetch(t *testing.T) {
 	}
 }
 
+func TestFetchIntoExistingRepository(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	gitDir, cleanup := createTempDir(t)
+	defer cleanup()
+	createTempGit(t, logger, gitDir)
+
+	targetPath, cleanup2 := createTempDir(t)
+	defer cleanup2()
+	spec := FetchSpec{URL: gitDir, Path: targetPath}
+	if err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() into an empty path: %v", err)
+	}
+
+	// A previous run left files behind, and the repository has a new commit since.
+	leftover := filepath.Join(targetPath, "leftover")
+	if err := ioutil.WriteFile(leftover, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, gitDir, "commit", "--allow-empty", "-m", "Second commit"); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() into an existing checkout: %v", err)
+	}
+	got, err := ShowCommit(logger, "HEAD", targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("expected the existing checkout to be at commit %s, got %s", want, got)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Errorf("expected the files left in the existing checkout to be removed, got %v", err)
+	}
+}
+
 func createTempDir(t *testing.T) (string, func()) {
 	dir, err := ioutil.TempDir("", "git-init-")
 	if err != nil {
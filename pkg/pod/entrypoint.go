@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -90,7 +91,10 @@ var (
 // Containers must have Command specified; if the user didn't specify a
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
-// Additionally, Step timeouts are added as entrypoint flag.
+// Steps in the same group run concurrently, once all of the steps before the
+// group have completed.
+// Additionally, Step timeouts, inactivity timeouts and retries are added as
+// entrypoint flags.
 func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec) (corev1.Container, []corev1.Container, error) {
 	initContainer := corev1.Container{
 		Name:  "place-tools",
@@ -105,12 +109,22 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 		return corev1.Container{}, nil, errors.New("No steps specified")
 	}
 
+	// Steps in the same stage run concurrently, and wait for all of the
+	// steps in the previous stage.
+	stages := stepStages(taskSpec, len(steps))
+	stageOf := make([]int, len(steps))
+	for k, stage := range stages {
+		for _, i := range stage {
+			stageOf[i] = k
+		}
+	}
+
 	for i, s := range steps {
 		var argsForEntrypoint []string
-		switch i {
+		switch stageOf[i] {
 		case 0:
 			argsForEntrypoint = []string{
-				// First step waits for the Downward volume file.
+				// First steps wait for the Downward volume file.
 				"-wait_file", filepath.Join(downwardMountPoint, downwardMountReadyFile),
 				"-wait_file_content", // Wait for file contents, not just an empty file.
 				// Start next step.
@@ -118,9 +132,13 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 				"-termination_path", terminationPath,
 			}
 		default:
-			// All other steps wait for previous file, write next file.
+			// All other steps wait for the previous stage's files, write next file.
+			var waitFiles []string
+			for _, j := range stages[stageOf[i]-1] {
+				waitFiles = append(waitFiles, filepath.Join(mountPoint, fmt.Sprintf("%d", j)))
+			}
 			argsForEntrypoint = []string{
-				"-wait_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i-1)),
+				"-wait_file", strings.Join(waitFiles, ","),
 				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
 				"-termination_path", terminationPath,
 			}
@@ -130,6 +148,15 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 && taskSpec.Steps[i].Timeout != nil {
 				argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 			}
+			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 && taskSpec.Steps[i].InactivityTimeout != nil {
+				argsForEntrypoint = append(argsForEntrypoint, "-inactivity_timeout", taskSpec.Steps[i].InactivityTimeout.Duration.String())
+			}
+			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 && taskSpec.Steps[i].Retries > 0 {
+				argsForEntrypoint = append(argsForEntrypoint, "-retries", strconv.Itoa(taskSpec.Steps[i].Retries))
+				if taskSpec.Steps[i].RetryDelay != nil {
+					argsForEntrypoint = append(argsForEntrypoint, "-retry_delay", taskSpec.Steps[i].RetryDelay.Duration.String())
+				}
+			}
 			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
 		}
 
@@ -149,12 +176,35 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 		steps[i].VolumeMounts = append(steps[i].VolumeMounts, toolsMount)
 		steps[i].TerminationMessagePath = terminationPath
 	}
-	// Mount the Downward volume into the first step container.
-	steps[0].VolumeMounts = append(steps[0].VolumeMounts, downwardMount)
+	// Mount the Downward volume into the first step containers.
+	for _, i := range stages[0] {
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, downwardMount)
+	}
 
 	return initContainer, steps, nil
 }
 
+// stepStages returns the indices of the steps grouped into the stages they
+// run in, in order. Consecutive steps in the same group run concurrently in
+// the same stage, and any other step runs alone in its own stage.
+func stepStages(taskSpec *v1beta1.TaskSpec, numSteps int) [][]int {
+	var stages [][]int
+	prevGroup := ""
+	for i := 0; i < numSteps; i++ {
+		group := ""
+		if taskSpec != nil && i < len(taskSpec.Steps) {
+			group = taskSpec.Steps[i].Group
+		}
+		if group != "" && group == prevGroup {
+			stages[len(stages)-1] = append(stages[len(stages)-1], i)
+		} else {
+			stages = append(stages, []int{i})
+		}
+		prevGroup = group
+	}
+	return stages
+}
+
 func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []string {
 	if len(results) == 0 {
 		return nil
@@ -107,6 +107,55 @@ func TestOrderContainers(t *testing.T) {
 	}
 }
 
+func TestOrderContainersWithStepGroups(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{
+			{Group: "setup"},
+			{Group: "setup"},
+			{},
+			{Group: "checks"},
+			{Group: "checks"},
+			{},
+		},
+	}
+	var steps []corev1.Container
+	for range taskSpec.Steps {
+		steps = append(steps, corev1.Container{Image: "image", Command: []string{"cmd"}})
+	}
+	step := func(waitArgs []string, post string, mounts ...corev1.VolumeMount) corev1.Container {
+		return corev1.Container{
+			Image:   "image",
+			Command: []string{entrypointBinary},
+			Args: append(waitArgs,
+				"-post_file", post,
+				"-termination_path", "/tekton/termination",
+				"-entrypoint", "cmd", "--",
+			),
+			VolumeMounts:           mounts,
+			TerminationMessagePath: "/tekton/termination",
+		}
+	}
+	waitForReady := []string{"-wait_file", "/tekton/downward/ready", "-wait_file_content"}
+	want := []corev1.Container{
+		// The first group waits for the Downward volume file.
+		step(waitForReady, "/tekton/tools/0", toolsMount, downwardMount),
+		step(waitForReady, "/tekton/tools/1", toolsMount, downwardMount),
+		// The step after a group waits for all of the group's steps.
+		step([]string{"-wait_file", "/tekton/tools/0,/tekton/tools/1"}, "/tekton/tools/2", toolsMount),
+		// The steps in a group all wait for the step before the group.
+		step([]string{"-wait_file", "/tekton/tools/2"}, "/tekton/tools/3", toolsMount),
+		step([]string{"-wait_file", "/tekton/tools/2"}, "/tekton/tools/4", toolsMount),
+		step([]string{"-wait_file", "/tekton/tools/3,/tekton/tools/4"}, "/tekton/tools/5", toolsMount),
+	}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestEntryPointResults(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Results: []v1beta1.TaskResult{{
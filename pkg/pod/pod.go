@@ -149,20 +149,55 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	initContainers = append(initContainers, entrypointInit)
 	volumes = append(volumes, toolsVolume, downwardVolume)
 
+	// If enabled, let the Task's steps report their progress.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepProgress {
+		stepContainers = addStepProgress(stepContainers, taskSteps(taskRun, taskSpec.Steps))
+		volumes = append(volumes, stepsVolume, podInfoVolume)
+	}
+
+	// If enabled, report the last lines of output of failed steps.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableOutputTail {
+		for i := range stepContainers {
+			stepContainers[i].Args = append([]string{"-output_tail"}, stepContainers[i].Args...)
+		}
+	}
+
 	limitRangeMin, err := getLimitRangeMinimum(ctx, taskRun.Namespace, b.KubeClient)
 	if err != nil {
 		return nil, err
 	}
 
-	// Zero out non-max resource requests.
-	stepContainers = resolveResourceRequests(stepContainers, limitRangeMin)
+	// Distribute the compute resources of the Task or TaskRun across the
+	// steps of the Task, if specified. Otherwise, zero out non-max resource
+	// requests.
+	if computeResources := getComputeResources(taskRun, taskSpec); computeResources != nil {
+		stepContainers = distributeComputeResources(stepContainers, taskSteps(taskRun, taskSpec.Steps), *computeResources, limitRangeMin)
+	} else {
+		stepContainers = resolveResourceRequests(stepContainers, stepStages(&taskSpec, len(stepContainers)), limitRangeMin)
+	}
+
+	// By default, use an empty pod template and take the one defined in the task run spec, merged
+	// into the default pod template, if any.
+	podTemplate := pod.Template{}
+
+	if tpl := pod.MergePodTemplateWithDefault(taskRun.Spec.PodTemplate, config.FromContextOrDefaults(ctx).Defaults.DefaultPodTemplate); tpl != nil {
+		podTemplate = *tpl
+	}
 
-	// Add implicit env vars.
+	// Add implicit env vars and the pod template's env vars.
 	// They're prepended to the list, so that if the user specified any
 	// themselves their value takes precedence.
 	for i, s := range stepContainers {
-		env := append(implicitEnvVars, s.Env...)
-		stepContainers[i].Env = env
+		env := append([]corev1.EnvVar{}, implicitEnvVars...)
+		for _, e := range podTemplate.Env {
+			if !hasEnvVar(s.Env, e.Name) {
+				env = append(env, e)
+			}
+		}
+		stepContainers[i].Env = append(env, s.Env...)
+		if len(podTemplate.EnvFrom) > 0 {
+			stepContainers[i].EnvFrom = append(append([]corev1.EnvFromSource{}, podTemplate.EnvFrom...), s.EnvFrom...)
+		}
 	}
 
 	// Add implicit volume mounts to each step, unless the step specifies
@@ -210,13 +245,6 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
-	// By default, use an empty pod template and take the one defined in the task run spec if any
-	podTemplate := pod.Template{}
-
-	if taskRun.Spec.PodTemplate != nil {
-		podTemplate = *taskRun.Spec.PodTemplate
-	}
-
 	// Add podTemplate Volumes to the explicitly declared use volumes
 	volumes = append(volumes, taskSpec.Volumes...)
 	volumes = append(volumes, podTemplate.Volumes...)
@@ -291,16 +319,27 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			RuntimeClassName:             podTemplate.RuntimeClassName,
 			AutomountServiceAccountToken: podTemplate.AutomountServiceAccountToken,
 			SchedulerName:                podTemplate.SchedulerName,
-			HostNetwork:                  podTemplate.HostNetwork,
+			HostNetwork:                  podTemplate.HostNetwork && !podTemplate.DisableHostNetwork,
 			DNSPolicy:                    dnsPolicy,
 			DNSConfig:                    podTemplate.DNSConfig,
 			EnableServiceLinks:           podTemplate.EnableServiceLinks,
 			PriorityClassName:            priorityClassName,
 			ImagePullSecrets:             podTemplate.ImagePullSecrets,
+			TopologySpreadConstraints:    podTemplate.TopologySpreadConstraints,
+			HostAliases:                  podTemplate.HostAliases,
 		},
 	}, nil
 }
 
+func hasEnvVar(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // MakeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func MakeLabels(s *v1beta1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -337,6 +376,37 @@ func nodeAffinityUsingAffinityAssistant(affinityAssistantName string) *corev1.Af
 	}
 }
 
+// getComputeResources returns the compute resources the TaskRun's steps need
+// altogether: those specified by the TaskRun, or else by the Task, if any.
+func getComputeResources(taskRun *v1beta1.TaskRun, taskSpec v1beta1.TaskSpec) *corev1.ResourceRequirements {
+	if taskRun.Spec.ComputeResources != nil {
+		return taskRun.Spec.ComputeResources
+	}
+	return taskSpec.ComputeResources
+}
+
+// taskSteps returns the indices of the steps of the Task among the steps of
+// the TaskSpec, which also has the steps the controller adds for resources and
+// workspaces. The steps of the Task are the ones of the TaskSpec recorded in
+// the status of the TaskRun, and all the steps if none is recorded. The steps
+// the controller adds are always named, and step names are unique.
+func taskSteps(taskRun *v1beta1.TaskRun, steps []v1beta1.Step) []int {
+	var names map[string]bool
+	if taskRun.Status.TaskSpec != nil {
+		names = map[string]bool{}
+		for _, s := range taskRun.Status.TaskSpec.Steps {
+			names[s.Name] = true
+		}
+	}
+	var indices []int
+	for i, s := range steps {
+		if names == nil || names[s.Name] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
 // getLimitRangeMinimum gets all LimitRanges in a namespace and
 // searches for if a container minimum is specified. Due to
 // https://github.com/kubernetes/kubernetes/issues/79496, the
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	stepsVolumeName = "tekton-internal-steps"
+	stepsDir        = "/tekton/steps"
+
+	podInfoVolumeName = "tekton-internal-pod-info"
+	podInfoDir        = "/tekton/pod-info"
+	podInfoNameFile   = "name"
+
+	// stepProgressAnnotationPrefix prefixes the annotations of the Pod the
+	// steps publish their progress to while they're running, followed by
+	// the position of each step in the Task.
+	stepProgressAnnotationPrefix = pipeline.GroupName + "/step-progress-"
+
+	progressAnnotationFlag = "-progress_annotation"
+)
+
+var (
+	stepsVolume = corev1.Volume{
+		Name:         stepsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	stepsMount = corev1.VolumeMount{
+		Name:      stepsVolumeName,
+		MountPath: stepsDir,
+	}
+	podInfoVolume = corev1.Volume{
+		Name: podInfoVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     podInfoNameFile,
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				}},
+			},
+		},
+	}
+	podInfoMount = corev1.VolumeMount{
+		Name:      podInfoVolumeName,
+		MountPath: podInfoDir,
+		ReadOnly:  true,
+	}
+)
+
+// stepProgressFile returns the path of the file the step at position i in
+// the Task may write its progress to.
+func stepProgressFile(i int) string {
+	return filepath.Join(stepsDir, strconv.Itoa(i), "progress")
+}
+
+// addStepProgress modifies the steps of the Task, at the taskSteps indices
+// among the steps, which must already have been rewritten to run the
+// entrypoint binary, so that each step's entrypoint publishes the progress
+// written to its progress file in an annotation of the Pod while the step is
+// running, and reports the last progress in its termination message. Steps
+// added by Tekton don't report any progress.
+func addStepProgress(steps []corev1.Container, taskSteps []int) []corev1.Container {
+	for pos, i := range taskSteps {
+		steps[i].Args = append([]string{
+			"-progress_file", stepProgressFile(pos),
+			progressAnnotationFlag, stepProgressAnnotationPrefix + strconv.Itoa(pos),
+			"-pod_name_file", filepath.Join(podInfoDir, podInfoNameFile),
+		}, steps[i].Args...)
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, stepsMount, podInfoMount)
+	}
+	return steps
+}
+
+// setRunningStepsProgress sets the progress of the steps that are still
+// running to the progress they most recently published in the annotations of
+// the pod. The progress of completed steps is read from their termination
+// message instead.
+func setRunningStepsProgress(steps []v1beta1.StepState, pod *corev1.Pod) {
+	annotations := map[string]string{}
+	for _, c := range pod.Spec.Containers {
+		for i := 0; i+1 < len(c.Args); i++ {
+			if c.Args[i] == progressAnnotationFlag {
+				annotations[c.Name] = c.Args[i+1]
+				break
+			}
+		}
+	}
+	for i, s := range steps {
+		if s.Terminated != nil {
+			continue
+		}
+		if a, ok := annotations[s.ContainerName]; ok && pod.Annotations[a] != "" {
+			steps[i].Progress = pod.Annotations[a]
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddStepProgressSkipsAddedSteps(t *testing.T) {
+	steps := []corev1.Container{{
+		Name: "step-git-clone",
+		Args: []string{"-entrypoint", "git"},
+	}, {
+		Name: "step-build",
+		Args: []string{"-entrypoint", "make"},
+	}}
+
+	got := addStepProgress(steps, []int{1})
+
+	want := []corev1.Container{{
+		Name: "step-git-clone",
+		Args: []string{"-entrypoint", "git"},
+	}, {
+		Name: "step-build",
+		Args: []string{
+			"-progress_file", "/tekton/steps/0/progress",
+			"-progress_annotation", "tekton.dev/step-progress-0",
+			"-pod_name_file", "/tekton/pod-info/name",
+			"-entrypoint", "make",
+		},
+		VolumeMounts: []corev1.VolumeMount{stepsMount, podInfoMount},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSetRunningStepsProgress(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"tekton.dev/step-progress-0": "suite 4/4",
+				"tekton.dev/step-progress-1": "suite 2/4",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "step-first",
+				Args: []string{"-progress_annotation", "tekton.dev/step-progress-0"},
+			}, {
+				Name: "step-second",
+				Args: []string{"-progress_annotation", "tekton.dev/step-progress-1"},
+			}, {
+				Name: "step-third",
+				Args: []string{"-progress_annotation", "tekton.dev/step-progress-2"},
+			}},
+		},
+	}
+	steps := []v1beta1.StepState{{
+		ContainerName: "step-first",
+		Progress:      "done",
+		ContainerState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{},
+		},
+	}, {
+		ContainerName: "step-second",
+		ContainerState: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+	}, {
+		ContainerName: "step-third",
+	}}
+
+	setRunningStepsProgress(steps, pod)
+
+	var got []string
+	for _, s := range steps {
+		got = append(got, s.Progress)
+	}
+	// The progress of completed steps comes from their termination message.
+	want := []string{"done", "suite 2/4", ""}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -32,21 +32,41 @@ func allZeroQty() corev1.ResourceList {
 	}
 }
 
-func resolveResourceRequests(containers []corev1.Container, limitRangeMin corev1.ResourceList) []corev1.Container {
+func resolveResourceRequests(containers []corev1.Container, stages [][]int, limitRangeMin corev1.ResourceList) []corev1.Container {
+	// Containers in the same stage run concurrently, so their requests are
+	// added up. Without stages, each container runs alone.
+	if stages == nil {
+		for i := range containers {
+			stages = append(stages, []int{i})
+		}
+	}
+	stageOf := make([]int, len(containers))
+	for s, stage := range stages {
+		for _, i := range stage {
+			stageOf[i] = s
+		}
+	}
+
 	max := allZeroQty()
 	resourceNames := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
-	maxIndicesByResource := make(map[corev1.ResourceName]int, len(resourceNames))
+	maxStagesByResource := make(map[corev1.ResourceName]int, len(resourceNames))
 	for _, resourceName := range resourceNames {
-		maxIndicesByResource[resourceName] = -1
+		maxStagesByResource[resourceName] = -1
 	}
 
-	// Find max resource requests and associated list indices for
-	// containers for CPU, memory, and ephemeral storage resources
-	for i, c := range containers {
-		for k, v := range c.Resources.Requests {
-			if v.Cmp(max[k]) > 0 {
-				maxIndicesByResource[k] = i
-				max[k] = v
+	// Find max resource requests and associated stages for CPU, memory,
+	// and ephemeral storage resources
+	for s, stage := range stages {
+		for _, resourceName := range resourceNames {
+			total := zeroQty.DeepCopy()
+			for _, i := range stage {
+				if v, ok := containers[i].Resources.Requests[resourceName]; ok {
+					total.Add(v)
+				}
+			}
+			if total.Cmp(max[resourceName]) > 0 {
+				maxStagesByResource[resourceName] = s
+				max[resourceName] = total
 			}
 		}
 	}
@@ -62,15 +82,15 @@ func resolveResourceRequests(containers []corev1.Container, limitRangeMin corev1
 		limitRangeMin[corev1.ResourceEphemeralStorage] = zeroQty
 	}
 
-	// Set all non max resource requests to 0. Leave max request at index
-	// originally defined to account for limit of step.
+	// Set all non max resource requests to 0. Leave max requests in the
+	// stage originally defined to account for limit of steps.
 	for i := range containers {
 		if containers[i].Resources.Requests == nil {
 			containers[i].Resources.Requests = limitRangeMin
 			continue
 		}
 		for _, resourceName := range resourceNames {
-			if maxIndicesByResource[resourceName] != i {
+			if _, ok := containers[i].Resources.Requests[resourceName]; !ok || maxStagesByResource[resourceName] != stageOf[i] {
 				containers[i].Resources.Requests[resourceName] = limitRangeMin[resourceName]
 			}
 		}
@@ -78,3 +98,86 @@ func resolveResourceRequests(containers []corev1.Container, limitRangeMin corev1
 
 	return containers
 }
+
+// distributeComputeResources sets the resources of the steps of the Task from
+// the compute resources the steps need altogether, replacing any resources the
+// steps specified. The requests and the limits are split evenly across the
+// steps, so that the Pod requests, and is limited to, the compute resources.
+// No request or limit is set below the LimitRange minimum. The other steps,
+// added by the controller for resources and workspaces, keep their own
+// resources, and request the minimum of the resources they don't specify.
+func distributeComputeResources(containers []corev1.Container, taskSteps []int, computeResources corev1.ResourceRequirements, limitRangeMin corev1.ResourceList) []corev1.Container {
+	for i := range containers {
+		if containers[i].Resources.Requests == nil {
+			containers[i].Resources.Requests = corev1.ResourceList{}
+		}
+		// Set the requests that aren't specified to the minimum, so that
+		// the LimitRange defaults don't apply to each step.
+		for name, min := range limitRangeMin {
+			if _, ok := containers[i].Resources.Requests[name]; !ok {
+				containers[i].Resources.Requests[name] = min
+			}
+		}
+	}
+	if len(taskSteps) == 0 {
+		return containers
+	}
+
+	// Like Kubernetes does for containers, default the requests to the
+	// limits.
+	requests := corev1.ResourceList{}
+	for name, limit := range computeResources.Limits {
+		requests[name] = limit
+	}
+	for name, request := range computeResources.Requests {
+		requests[name] = request
+	}
+
+	for _, i := range taskSteps {
+		containers[i].Resources = corev1.ResourceRequirements{Requests: corev1.ResourceList{}}
+		for name, min := range limitRangeMin {
+			containers[i].Resources.Requests[name] = min
+		}
+	}
+	for name, request := range requests {
+		for j, share := range splitQuantity(name, request, len(taskSteps)) {
+			c := &containers[taskSteps[j]]
+			c.Resources.Requests[name] = maxQuantity(share, limitRangeMin[name])
+		}
+	}
+	for name, limit := range computeResources.Limits {
+		for j, share := range splitQuantity(name, limit, len(taskSteps)) {
+			c := &containers[taskSteps[j]]
+			if c.Resources.Limits == nil {
+				c.Resources.Limits = corev1.ResourceList{}
+			}
+			c.Resources.Limits[name] = maxQuantity(share, c.Resources.Requests[name])
+		}
+	}
+	return containers
+}
+
+// splitQuantity splits q into n quantities that add up to q, as evenly as
+// possible. CPU is split in millicores, and other resources in units.
+func splitQuantity(name corev1.ResourceName, q resource.Quantity, n int) []resource.Quantity {
+	value, newQuantity := q.Value(), resource.NewQuantity
+	if name == corev1.ResourceCPU {
+		value, newQuantity = q.MilliValue(), resource.NewMilliQuantity
+	}
+	shares := make([]resource.Quantity, n)
+	for i := range shares {
+		v := value / int64(n)
+		if int64(i) < value%int64(n) {
+			v++
+		}
+		shares[i] = *newQuantity(v, q.Format)
+	}
+	return shares
+}
+
+func maxQuantity(a, b resource.Quantity) resource.Quantity {
+	if b.Cmp(a) > 0 {
+		return b
+	}
+	return a
+}
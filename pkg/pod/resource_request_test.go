@@ -207,7 +207,7 @@ func TestResolveResourceRequests_No_LimitRange(t *testing.T) {
 		},
 	} {
 		t.Run(c.desc, func(t *testing.T) {
-			got := resolveResourceRequests(c.in, allZeroQty())
+			got := resolveResourceRequests(c.in, nil, allZeroQty())
 			if d := cmp.Diff(c.want, got, resourceQuantityCmp); d != "" {
 				t.Errorf("Diff %s", diff.PrintWantGot(d))
 			}
@@ -332,7 +332,7 @@ func TestResolveResourceRequests_LimitRange(t *testing.T) {
 	},
 	} {
 		t.Run(c.desc, func(t *testing.T) {
-			got := resolveResourceRequests(c.in, corev1.ResourceList{
+			got := resolveResourceRequests(c.in, nil, corev1.ResourceList{
 				corev1.ResourceCPU:              resource.MustParse("100m"),
 				corev1.ResourceMemory:           resource.MustParse("99Mi"),
 				corev1.ResourceEphemeralStorage: resource.MustParse("100m"),
@@ -344,3 +344,179 @@ func TestResolveResourceRequests_LimitRange(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveResourceRequests_Stages(t *testing.T) {
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	in := []corev1.Container{
+		{Resources: requests("3", "4Gi")},
+		// These run concurrently, so together they need more CPU than the
+		// first step, but less memory.
+		{Resources: requests("2", "1Gi")},
+		{Resources: requests("2", "1Gi")},
+	}
+	want := []corev1.Container{{
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              zeroQty,
+			corev1.ResourceMemory:           resource.MustParse("4Gi"),
+			corev1.ResourceEphemeralStorage: zeroQty,
+		}},
+	}, {
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("2"),
+			corev1.ResourceMemory:           zeroQty,
+			corev1.ResourceEphemeralStorage: zeroQty,
+		}},
+	}, {
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("2"),
+			corev1.ResourceMemory:           zeroQty,
+			corev1.ResourceEphemeralStorage: zeroQty,
+		}},
+	}}
+	got := resolveResourceRequests(in, [][]int{{0}, {1, 2}}, allZeroQty())
+	if d := cmp.Diff(want, got, resourceQuantityCmp); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestDistributeComputeResources(t *testing.T) {
+	for _, c := range []struct {
+		desc             string
+		in               []corev1.Container
+		taskSteps        []int
+		computeResources corev1.ResourceRequirements
+		limitRangeMin    corev1.ResourceList
+		want             []corev1.Container
+	}{{
+		desc: "requests and limits split evenly",
+		in: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")},
+			},
+		}, {}, {}},
+		taskSteps: []int{0, 1, 2},
+		computeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		limitRangeMin: allZeroQty(),
+		want: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("334m"),
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("333m"),
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("333m"),
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		}},
+	}, {
+		desc: "requests default to limits, steps added by the controller keep their resources",
+		in: []corev1.Container{{
+			Name: "git-init",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+		}, {}, {}},
+		taskSteps: []int{1, 2},
+		computeResources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+		},
+		limitRangeMin: allZeroQty(),
+		want: []corev1.Container{{
+			Name: "git-init",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("100m"),
+					corev1.ResourceMemory:           zeroQty,
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("1500m"),
+					corev1.ResourceMemory:           zeroQty,
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("1500m"),
+					corev1.ResourceMemory:           zeroQty,
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+			},
+		}},
+	}, {
+		desc:      "limit range minimum",
+		in:        []corev1.Container{{}, {}},
+		taskSteps: []int{0, 1},
+		computeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("300Mi")},
+		},
+		limitRangeMin: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("100m"),
+			corev1.ResourceMemory:           resource.MustParse("150Mi"),
+			corev1.ResourceEphemeralStorage: zeroQty,
+		},
+		want: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("100m"),
+					corev1.ResourceMemory:           resource.MustParse("150Mi"),
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("150Mi")},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("100m"),
+					corev1.ResourceMemory:           resource.MustParse("150Mi"),
+					corev1.ResourceEphemeralStorage: zeroQty,
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("150Mi")},
+			},
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := distributeComputeResources(c.in, c.taskSteps, c.computeResources, c.limitRangeMin)
+			if d := cmp.Diff(c.want, got, resourceQuantityCmp); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -19,14 +19,17 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -70,6 +73,10 @@ const (
 
 const oomKilled = "OOMKilled"
 
+// maxOutputTailLength bounds how much of a failed step's output is included
+// in the TaskRun's failure message.
+const maxOutputTailLength = 1024
+
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
 func SidecarsReady(podStatus corev1.PodStatus) bool {
@@ -132,6 +139,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 		merr = multierror.Append(merr, err)
 	}
 
+	setRunningStepsProgress(trs.Steps, pod)
 	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
 
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
@@ -144,6 +152,11 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		stepState := v1beta1.StepState{
+			Name:          trimStepPrefix(s.Name),
+			ContainerName: s.Name,
+			ImageID:       s.ImageID,
+		}
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -172,14 +185,14 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				if time != nil {
 					s.State.Terminated.StartedAt = *time
 				}
+				if err := setStepMetadataFromResults(&stepState, results); err != nil {
+					logger.Errorf("error reading the metadata of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
 			}
 		}
-		trs.Steps = append(trs.Steps, v1beta1.StepState{
-			ContainerState: *s.State.DeepCopy(),
-			Name:           trimStepPrefix(s.Name),
-			ContainerName:  s.Name,
-			ImageID:        s.ImageID,
-		})
+		stepState.ContainerState = *s.State.DeepCopy()
+		trs.Steps = append(trs.Steps, stepState)
 	}
 
 	return merr
@@ -268,6 +281,84 @@ func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (
 	return nil, nil
 }
 
+// setStepMetadataFromResults sets the exit code, attempts, duration,
+// termination reason, resource usage and progress of a step as reported by the
+// entrypoint in its termination message.
+func setStepMetadataFromResults(state *v1beta1.StepState, results []v1beta1.PipelineResourceResult) error {
+	var startedAt, completedAt time.Time
+	for _, result := range results {
+		if result.ResultType != v1beta1.InternalTektonResultType {
+			continue
+		}
+		switch result.Key {
+		case "ExitCode":
+			c, err := strconv.ParseInt(result.Value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("could not parse exit code value %q in ExitCode field: %w", result.Value, err)
+			}
+			exitCode := int32(c)
+			state.ExitCode = &exitCode
+		case "Attempts":
+			a, err := strconv.Atoi(result.Value)
+			if err != nil {
+				return fmt.Errorf("could not parse attempts value %q in Attempts field: %w", result.Value, err)
+			}
+			state.Attempts = a
+		case "StartedAt", "CompletedAt":
+			t, err := time.Parse(timeFormat, result.Value)
+			if err != nil {
+				return fmt.Errorf("could not parse time value %q in %s field: %w", result.Value, result.Key, err)
+			}
+			if result.Key == "StartedAt" {
+				startedAt = t
+			} else {
+				completedAt = t
+			}
+		case "Reason":
+			state.TerminationReason = result.Value
+		case "Progress":
+			state.Progress = result.Value
+		case "UserCPUTime", "SystemCPUTime":
+			d, err := time.ParseDuration(result.Value)
+			if err != nil {
+				return fmt.Errorf("could not parse duration value %q in %s field: %w", result.Value, result.Key, err)
+			}
+			usage := resourceUsage(state)
+			if result.Key == "UserCPUTime" {
+				usage.UserCPUTime = &metav1.Duration{Duration: d}
+			} else {
+				usage.SystemCPUTime = &metav1.Duration{Duration: d}
+			}
+		case "MaxRSS", "FilesystemReads", "FilesystemWrites":
+			n, err := strconv.ParseInt(result.Value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("could not parse value %q in %s field: %w", result.Value, result.Key, err)
+			}
+			usage := resourceUsage(state)
+			switch result.Key {
+			case "MaxRSS":
+				usage.MaxRSS = resource.NewQuantity(n, resource.BinarySI)
+			case "FilesystemReads":
+				usage.FilesystemReads = n
+			case "FilesystemWrites":
+				usage.FilesystemWrites = n
+			}
+		}
+	}
+	if !startedAt.IsZero() && !completedAt.IsZero() {
+		state.Duration = &metav1.Duration{Duration: completedAt.Sub(startedAt)}
+	}
+	return nil
+}
+
+// resourceUsage returns the ResourceUsage of state, initializing it if needed.
+func resourceUsage(state *v1beta1.StepState) *v1beta1.StepResourceUsage {
+	if state.ResourceUsage == nil {
+		state.ResourceUsage = &v1beta1.StepResourceUsage{}
+	}
+	return state.ResourceUsage
+}
+
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
@@ -326,6 +417,24 @@ func areStepsComplete(pod *corev1.Pod) bool {
 	return stepsComplete
 }
 
+// outputTailMessage returns the tail of a failed step's output, as reported by
+// the entrypoint, formatted to be appended to the failure message. The tail is
+// truncated to maxOutputTailLength so that the message stays readable.
+func outputTailMessage(tail string) string {
+	tail = strings.TrimRight(tail, "\n")
+	if tail == "" {
+		return ""
+	}
+	if len(tail) > maxOutputTailLength {
+		tail = tail[len(tail)-maxOutputTailLength:]
+		for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+			tail = tail[1:]
+		}
+		tail = "..." + tail
+	}
+	return "last lines of output:\n" + tail + "\n"
+}
+
 func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	// First, try to surface an error about the actual build step that failed.
 	for _, status := range pod.Status.ContainerStatuses {
@@ -333,19 +442,31 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 		if term != nil {
 			msg := status.State.Terminated.Message
 			r, _ := termination.ParseMessage(logger, msg)
+			var tail string
+			for _, result := range r {
+				if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "OutputTail" {
+					tail = outputTailMessage(result.Value)
+				}
+			}
 			for _, result := range r {
 				if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == "TimeoutExceeded" {
 					// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
 					return fmt.Sprintf("%q exited because the step exceeded the specified timeout limit; for logs run: kubectl -n %s logs %s -c %s\n",
 						status.Name,
-						pod.Namespace, pod.Name, status.Name)
+						pod.Namespace, pod.Name, status.Name) + tail
+				}
+				if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == "InactivityTimeoutExceeded" {
+					// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
+					return fmt.Sprintf("%q exited because the step produced no output within the specified inactivity timeout; for logs run: kubectl -n %s logs %s -c %s\n",
+						status.Name,
+						pod.Namespace, pod.Name, status.Name) + tail
 				}
 			}
 			if term.ExitCode != 0 {
 				// Newline required at end to prevent yaml parser from breaking the log help text at 80 chars
 				return fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s\n",
 					status.Name, term.ExitCode, status.ImageID,
-					pod.Namespace, pod.Name, status.Name)
+					pod.Namespace, pod.Name, status.Name) + tail
 			}
 		}
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -25,10 +26,12 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
 )
 
 var ignoreVolatileTime = cmp.Comparer(func(_, _ apis.VolatileTime) bool { return true })
@@ -667,6 +670,130 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step metadata from termination message",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-failure",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 7,
+						Message:  `[{"key":"StartedAt","value":"2020-01-01T00:00:00.000Z","type":"InternalTektonResult"},{"key":"Attempts","value":"3","type":"InternalTektonResult"},{"key":"ExitCode","value":"7","type":"InternalTektonResult"},{"key":"CompletedAt","value":"2020-01-01T00:00:05.500Z","type":"InternalTektonResult"},{"key":"Reason","value":"Error","type":"InternalTektonResult"},{"key":"UserCPUTime","value":"1.5s","type":"InternalTektonResult"},{"key":"SystemCPUTime","value":"250ms","type":"InternalTektonResult"},{"key":"MaxRSS","value":"67108864","type":"InternalTektonResult"},{"key":"FilesystemReads","value":"8","type":"InternalTektonResult"},{"key":"FilesystemWrites","value":"16","type":"InternalTektonResult"},{"key":"Progress","value":"3/4 suites","type":"InternalTektonResult"}]`,
+					},
+				},
+			}, {
+				Name: "step-skipped",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `[{"key":"StartedAt","value":"2020-01-01T00:00:05.600Z","type":"InternalTektonResult"},{"key":"CompletedAt","value":"2020-01-01T00:00:05.600Z","type":"InternalTektonResult"},{"key":"Reason","value":"Skipped","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure("\"step-failure\" exited with code 7 (image: \"\"); for logs run: kubectl -n foo logs pod -c step-failure\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:  7,
+							StartedAt: metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+						}},
+					Name:              "failure",
+					ContainerName:     "step-failure",
+					ExitCode:          ptr.Int32(7),
+					Attempts:          3,
+					Duration:          &metav1.Duration{Duration: 5500 * time.Millisecond},
+					TerminationReason: "Error",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						UserCPUTime:      &metav1.Duration{Duration: 1500 * time.Millisecond},
+						SystemCPUTime:    &metav1.Duration{Duration: 250 * time.Millisecond},
+						MaxRSS:           resource.NewQuantity(64*1024*1024, resource.BinarySI),
+						FilesystemReads:  8,
+						FilesystemWrites: 16,
+					},
+					Progress: "3/4 suites",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:  1,
+							StartedAt: metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 5, 600000000, time.UTC)),
+						}},
+					Name:              "skipped",
+					ContainerName:     "step-skipped",
+					Duration:          &metav1.Duration{},
+					TerminationReason: "Skipped",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step hit inactivity timeout",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-hung",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `[{"key":"Reason","value":"InactivityTimeoutExceeded","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure("\"step-hung\" exited because the step produced no output within the specified inactivity timeout; for logs run: kubectl -n foo logs pod -c step-hung\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+						}},
+					Name:              "hung",
+					ContainerName:     "step-hung",
+					TerminationReason: "InactivityTimeoutExceeded",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "failure message includes the tail of the step's output",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-build",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 2,
+						Message:  `[{"key":"OutputTail","value":"compiling...\nmain.go:3: undefined: foo\n","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure("\"step-build\" exited with code 2 (image: \"image-id\"); for logs run: kubectl -n foo logs pod -c step-build\nlast lines of output:\ncompiling...\nmain.go:3: undefined: foo\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 2,
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "taskrun status set to failed if task fails",
 		podStatus: corev1.PodStatus{
@@ -1253,3 +1380,37 @@ func TestSortPodContainerStatuses(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputTailMessage(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		tail string
+		want string
+	}{{
+		desc: "empty",
+		tail: "",
+		want: "",
+	}, {
+		desc: "only newlines",
+		tail: "\n\n",
+		want: "",
+	}, {
+		desc: "short tail",
+		tail: "error: boom\n",
+		want: "last lines of output:\nerror: boom\n",
+	}, {
+		desc: "long tail is truncated from the front",
+		tail: strings.Repeat("a", maxOutputTailLength) + "bcd",
+		want: "last lines of output:\n..." + strings.Repeat("a", maxOutputTailLength-3) + "bcd\n",
+	}, {
+		desc: "truncation doesn't split runes",
+		tail: "é" + strings.Repeat("a", maxOutputTailLength-1),
+		want: "last lines of output:\n..." + strings.Repeat("a", maxOutputTailLength-1) + "\n",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := outputTailMessage(c.tail); got != c.want {
+				t.Errorf("outputTailMessage() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -119,6 +119,27 @@ func GetSchedulable(g *Graph, doneTasks ...string) (sets.String, error) {
 	return d, nil
 }
 
+// GetAncestors returns the names of all the tasks that precede the task in the graph,
+// directly or through other tasks.
+func GetAncestors(g *Graph, name string) sets.String {
+	ancestors := sets.NewString()
+	n, ok := g.Nodes[name]
+	if !ok {
+		return ancestors
+	}
+	queue := append([]*Node(nil), n.Prev...)
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if ancestors.Has(n.Task.HashKey()) {
+			continue
+		}
+		ancestors.Insert(n.Task.HashKey())
+		queue = append(queue, n.Prev...)
+	}
+	return ancestors
+}
+
 func linkPipelineTasks(prev *Node, next *Node) error {
 	// Check for self cycle
 	if prev.Task.HashKey() == next.Task.HashKey() {
@@ -86,6 +86,32 @@ func TestGetSchedulable(t *testing.T) {
 	}
 }
 
+func TestGetAncestors(t *testing.T) {
+	g := testGraph(t)
+	for _, tc := range []struct {
+		name string
+		want sets.String
+	}{{
+		name: "a",
+		want: sets.NewString(),
+	}, {
+		name: "y",
+		want: sets.NewString("a", "x"),
+	}, {
+		name: "w",
+		want: sets.NewString("a", "b", "x", "y"),
+	}, {
+		name: "unknown",
+		want: sets.NewString(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, dag.GetAncestors(g, tc.name)); d != "" {
+				t.Errorf("unexpected ancestors of %s %s", tc.name, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetSchedulable_Invalid(t *testing.T) {
 	g := testGraph(t)
 	tcs := []struct {
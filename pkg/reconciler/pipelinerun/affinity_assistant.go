@@ -43,41 +43,61 @@ const (
 	featureFlagDisableAffinityAssistantKey = "disable-affinity-assistant"
 )
 
-// createAffinityAssistants creates an Affinity Assistant StatefulSet for every workspace in the PipelineRun that
-// use a PersistentVolumeClaim volume. This is done to achieve Node Affinity for all TaskRuns that
-// share the workspace volume and make it possible for the tasks to execute parallel while sharing volume.
+// createAffinityAssistants creates a single Affinity Assistant StatefulSet for the PipelineRun when any of its
+// workspaces use a PersistentVolumeClaim volume. The Affinity Assistant mounts all of those volumes. This is done
+// to achieve Node Affinity for all TaskRuns that use any of the workspace volumes and make it possible for the
+// tasks to execute parallel while sharing volumes.
 func (c *Reconciler) createAffinityAssistants(ctx context.Context, wb []v1beta1.WorkspaceBinding, pr *v1beta1.PipelineRun, namespace string) error {
 	logger := logging.FromContext(ctx)
 
-	var errs []error
+	var claimNames []string
+	seen := make(map[string]bool)
+	cacheKeyReplacements := volumeclaim.PipelineRunCacheKeyReplacements(pr)
 	for _, w := range wb {
-		if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
-			affinityAssistantName := getAffinityAssistantName(w.Name, pr.Name)
-			_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Get(ctx, affinityAssistantName, metav1.GetOptions{})
-			claimName := getClaimName(w, pr.GetOwnerReference())
-			switch {
-			case apierrors.IsNotFound(err):
-				affinityAssistantStatefulSet := affinityAssistantStatefulSet(affinityAssistantName, pr, claimName, c.Images.NopImage)
-				_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Create(ctx, affinityAssistantStatefulSet, metav1.CreateOptions{})
-				if err != nil {
-					errs = append(errs, fmt.Errorf("failed to create StatefulSet %s: %s", affinityAssistantName, err))
-				}
-				if err == nil {
-					logger.Infof("Created StatefulSet %s in namespace %s", affinityAssistantName, namespace)
-				}
-			case err != nil:
-				errs = append(errs, fmt.Errorf("failed to retrieve StatefulSet %s: %s", affinityAssistantName, err))
+		if usesPersistentVolumeClaim(w) {
+			claimName := getClaimName(w, pr, cacheKeyReplacements)
+			if !seen[claimName] {
+				seen[claimName] = true
+				claimNames = append(claimNames, claimName)
 			}
 		}
 	}
-	return errorutils.NewAggregate(errs)
+	if len(claimNames) == 0 {
+		return nil
+	}
+
+	affinityAssistantName := getAffinityAssistantName(pr.Name)
+	_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Get(ctx, affinityAssistantName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		affinityAssistantStatefulSet := affinityAssistantStatefulSet(affinityAssistantName, pr, claimNames, c.Images.NopImage)
+		if _, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Create(ctx, affinityAssistantStatefulSet, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create StatefulSet %s: %s", affinityAssistantName, err)
+		}
+		logger.Infof("Created StatefulSet %s in namespace %s", affinityAssistantName, namespace)
+	case err != nil:
+		return fmt.Errorf("failed to retrieve StatefulSet %s: %s", affinityAssistantName, err)
+	}
+	return nil
+}
+
+// usesPersistentVolumeClaim returns whether the workspace binding is backed by
+// a PersistentVolumeClaim shared by the TaskRuns that use it, which needs an
+// Affinity Assistant. The other volumes, including CSI and projected volumes,
+// are created separately for each TaskRun's Pod. Caches keyed by results are left out, as their
+// PersistentVolumeClaims are only known once the results are produced.
+func usesPersistentVolumeClaim(w v1beta1.WorkspaceBinding) bool {
+	return w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil || (w.Cache != nil && !volumeclaim.CacheKeyReferencesResults(w))
 }
 
-func getClaimName(w v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference) string {
-	if w.PersistentVolumeClaim != nil {
+func getClaimName(w v1beta1.WorkspaceBinding, pr *v1beta1.PipelineRun, cacheKeyReplacements map[string]string) string {
+	switch {
+	case w.PersistentVolumeClaim != nil:
 		return w.PersistentVolumeClaim.ClaimName
-	} else if w.VolumeClaimTemplate != nil {
-		return volumeclaim.GetPersistentVolumeClaimName(w.VolumeClaimTemplate, w, ownerReference)
+	case w.VolumeClaimTemplate != nil:
+		return volumeclaim.GetPersistentVolumeClaimName(w.VolumeClaimTemplate, w, pr.GetOwnerReference())
+	case w.Cache != nil:
+		return volumeclaim.GetCachePersistentVolumeClaimName(w, cacheKeyReplacements)
 	}
 
 	return ""
@@ -90,24 +110,42 @@ func (c *Reconciler) cleanupAffinityAssistants(ctx context.Context, pr *v1beta1.
 		return nil
 	}
 
-	var errs []error
+	// PipelineRuns started before the Affinity Assistant was shared by all the
+	// workspaces have one Affinity Assistant per PersistentVolumeClaim workspace
+	var affinityAssistantStsNames []string
 	for _, w := range pr.Spec.Workspaces {
-		if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
-			affinityAssistantStsName := getAffinityAssistantName(w.Name, pr.Name)
-			if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantStsName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
-				errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %s", affinityAssistantStsName, err))
-			}
+		if usesPersistentVolumeClaim(w) {
+			affinityAssistantStsNames = append(affinityAssistantStsNames, getWorkspaceAffinityAssistantName(w.Name, pr.Name))
+		}
+	}
+	if len(affinityAssistantStsNames) == 0 {
+		return nil
+	}
+	affinityAssistantStsNames = append(affinityAssistantStsNames, getAffinityAssistantName(pr.Name))
+
+	var errs []error
+	for _, affinityAssistantStsName := range affinityAssistantStsNames {
+		if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantStsName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %s", affinityAssistantStsName, err))
 		}
 	}
 	return errorutils.NewAggregate(errs)
 }
 
-func getAffinityAssistantName(pipelineWorkspaceName string, pipelineRunName string) string {
-	hashBytes := sha256.Sum256([]byte(pipelineWorkspaceName + pipelineRunName))
+// getAffinityAssistantName returns the name of the Affinity Assistant shared by
+// all the PersistentVolumeClaim workspaces of a PipelineRun.
+func getAffinityAssistantName(pipelineRunName string) string {
+	hashBytes := sha256.Sum256([]byte(pipelineRunName))
 	hashString := fmt.Sprintf("%x", hashBytes)
 	return fmt.Sprintf("%s-%s", "affinity-assistant", hashString[:10])
 }
 
+// getWorkspaceAffinityAssistantName returns the name of the Affinity Assistant
+// previously created for each PersistentVolumeClaim workspace of a PipelineRun.
+func getWorkspaceAffinityAssistantName(pipelineWorkspaceName string, pipelineRunName string) string {
+	return getAffinityAssistantName(pipelineWorkspaceName + pipelineRunName)
+}
+
 func getStatefulSetLabels(pr *v1beta1.PipelineRun, affinityAssistantName string) map[string]string {
 	// Propagate labels from PipelineRun to StatefulSet.
 	labels := make(map[string]string, len(pr.ObjectMeta.Labels)+1)
@@ -123,7 +161,7 @@ func getStatefulSetLabels(pr *v1beta1.PipelineRun, affinityAssistantName string)
 	return labels
 }
 
-func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimName string, affinityAssistantImage string) *appsv1.StatefulSet {
+func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNames []string, affinityAssistantImage string) *appsv1.StatefulSet {
 	// We want a singleton pod
 	replicas := int32(1)
 
@@ -159,6 +197,26 @@ func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNam
 		},
 	}}
 
+	// A Pod mounting a PersistentVolumeClaim that has a StorageClass with
+	// volumeBindingMode: Immediate
+	// the PV is allocated on a Node first, and then the pod need to be
+	// scheduled to that node.
+	// To support those PVCs, the Affinity Assistant must also mount the
+	// same PersistentVolumeClaims - to be sure that the Affinity Assistant
+	// pod is scheduled to the same Availability Zone as the PVs, when using
+	// a regional cluster. This is called VolumeScheduling.
+	volumes := make([]corev1.Volume, 0, len(claimNames))
+	for i, claimName := range claimNames {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("workspace-%d", i),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+	}
+
 	// use podAntiAffinity to repel other affinity assistants
 	repelOtherAffinityAssistantsPodAffinityTerm := corev1.WeightedPodAffinityTerm{
 		Weight: 100,
@@ -200,22 +258,7 @@ func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNam
 							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{repelOtherAffinityAssistantsPodAffinityTerm},
 						},
 					},
-					Volumes: []corev1.Volume{{
-						Name: "workspace",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-
-								// A Pod mounting a PersistentVolumeClaim that has a StorageClass with
-								// volumeBindingMode: Immediate
-								// the PV is allocated on a Node first, and then the pod need to be
-								// scheduled to that node.
-								// To support those PVCs, the Affinity Assistant must also mount the
-								// same PersistentVolumeClaim - to be sure that the Affinity Assistant
-								// pod is scheduled to the same Availability Zone as the PV, when using
-								// a regional cluster. This is called VolumeScheduling.
-								ClaimName: claimName,
-							}},
-					}},
+					Volumes: volumes,
 				},
 			},
 		},
@@ -20,13 +20,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
@@ -68,7 +71,7 @@ func TestCreateAndDeleteOfAffinityAssistant(t *testing.T) {
 		t.Errorf("unexpected error from createAffinityAssistants: %v", err)
 	}
 
-	expectedAffinityAssistantName := getAffinityAssistantName(workspaceName, testPipelineRun.Name)
+	expectedAffinityAssistantName := getAffinityAssistantName(testPipelineRun.Name)
 	_, err = c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).Get(ctx, expectedAffinityAssistantName, metav1.GetOptions{})
 	if err != nil {
 		t.Errorf("unexpected error when retrieving StatefulSet: %v", err)
@@ -85,6 +88,119 @@ func TestCreateAndDeleteOfAffinityAssistant(t *testing.T) {
 	}
 }
 
+// TestCleanupOfPerWorkspaceAffinityAssistants tests that the Affinity Assistants created for each
+// PVC workspace of a PipelineRun started before they were shared are deleted along with the shared one
+func TestCleanupOfPerWorkspaceAffinityAssistants(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	testPipelineRun := &v1beta1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun-1",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-claim",
+				},
+			}, {
+				Name: "cache",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "cache-claim",
+				},
+			}},
+		},
+	}
+	names := []string{
+		getWorkspaceAffinityAssistantName("source", testPipelineRun.Name),
+		getWorkspaceAffinityAssistantName("cache", testPipelineRun.Name),
+	}
+	var objs []runtime.Object
+	for _, name := range names {
+		objs = append(objs, affinityAssistantStatefulSet(name, testPipelineRun, nil, ""))
+	}
+	c := Reconciler{
+		KubeClientSet: fakek8s.NewSimpleClientset(objs...),
+		Images:        pipeline.Images{},
+	}
+
+	if err := c.cleanupAffinityAssistants(ctx, testPipelineRun); err != nil {
+		t.Errorf("unexpected error from cleanupAffinityAssistants: %v", err)
+	}
+
+	for _, name := range names {
+		_, err := c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("expected a NotFound response for StatefulSet %s, got: %v", name, err)
+		}
+	}
+}
+
+// TestCreateAffinityAssistantWithMultiplePVCWorkspaces tests that a single Affinity Assistant
+// mounting each distinct PersistentVolumeClaim is created for a PipelineRun with several PVC workspaces
+func TestCreateAffinityAssistantWithMultiplePVCWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := Reconciler{
+		KubeClientSet: fakek8s.NewSimpleClientset(),
+		Images:        pipeline.Images{},
+	}
+
+	testPipelineRun := &v1beta1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun-1",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-claim",
+				},
+			}, {
+				Name: "cache",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "cache-claim",
+				},
+			}, {
+				Name:    "cache-subdir",
+				SubPath: "subdir",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "cache-claim",
+				},
+			}, {
+				Name:     "scratch",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+
+	if err := c.createAffinityAssistants(ctx, testPipelineRun.Spec.Workspaces, testPipelineRun, testPipelineRun.Namespace); err != nil {
+		t.Errorf("unexpected error from createAffinityAssistants: %v", err)
+	}
+
+	statefulSets, err := c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing StatefulSets: %v", err)
+	}
+	if len(statefulSets.Items) != 1 {
+		t.Fatalf("expected one StatefulSet, got %d", len(statefulSets.Items))
+	}
+
+	var claimNames []string
+	for _, v := range statefulSets.Items[0].Spec.Template.Spec.Volumes {
+		claimNames = append(claimNames, v.PersistentVolumeClaim.ClaimName)
+	}
+	if d := cmp.Diff([]string{"source-claim", "cache-claim"}, claimNames); d != "" {
+		t.Errorf("unexpected claims mounted by the Affinity Assistant %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestThatCustomTolerationsAndNodeSelectorArePropagatedToAffinityAssistant(t *testing.T) {
 	prWithCustomPodTemplate := &v1beta1.PipelineRun{
 		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
@@ -106,7 +222,7 @@ func TestThatCustomTolerationsAndNodeSelectorArePropagatedToAffinityAssistant(t
 		},
 	}
 
-	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, "mypvc", "nginx")
+	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, []string{"mypvc"}, "nginx")
 
 	if len(stsWithTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 1 {
 		t.Errorf("expected Tolerations in the StatefulSet")
@@ -126,7 +242,7 @@ func TestThatTheAffinityAssistantIsWithoutNodeSelectorAndTolerations(t *testing.
 		Spec: v1beta1.PipelineRunSpec{},
 	}
 
-	stsWithoutTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithoutCustomPodTemplate, "mypvc", "nginx")
+	stsWithoutTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithoutCustomPodTemplate, []string{"mypvc"}, "nginx")
 
 	if len(stsWithoutTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 0 {
 		t.Errorf("unexpected Tolerations in the StatefulSet")
@@ -144,9 +260,7 @@ func TestThatTheAffinityAssistantIsWithoutNodeSelectorAndTolerations(t *testing.
 // plus 10 chars for a hash. Labels in Kubernetes can not be longer than 63 chars.
 // Typical output from the example below is affinity-assistant-0384086f62
 func TestThatAffinityAssistantNameIsNoLongerThan53(t *testing.T) {
-	affinityAssistantName := getAffinityAssistantName(
-		"pipeline-workspace-name-that-is-quite-long",
-		"pipelinerun-with-a-long-custom-name")
+	affinityAssistantName := getAffinityAssistantName("pipelinerun-with-a-long-custom-name")
 
 	if len(affinityAssistantName) > 53 {
 		t.Errorf("affinity assistant name can not be longer than 53 chars")
@@ -34,11 +34,14 @@ import (
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/resource/injection/informers/resource/v1alpha1/pipelineresource"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracker"
@@ -58,6 +61,7 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 		pipelineInformer := pipelineinformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		conditionInformer := conditioninformer.Get(ctx)
+		podInformer := podinformer.Get(ctx)
 		metrics, err := NewRecorder()
 		if err != nil {
 			logger.Errorf("Failed to create pipelinerun metrics recorder %v", err)
@@ -77,7 +81,7 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 			conditionLister:   conditionInformer.Lister(),
 			cloudEventClient:  cloudeventclient.Get(ctx),
 			metrics:           metrics,
-			pvcHandler:        volumeclaim.NewPVCHandler(kubeclientset, logger),
+			pvcHandler:        volumeclaim.NewPVCHandler(kubeclientset, logger, podInformer.Lister(), pipelineRunInformer.Lister(), taskRunInformer.Lister(), volumesnapshot.NewSnapshotHandler(dynamicclient.Get(ctx), logger)),
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			configStore := config.NewStore(logger.Named("config-store"))
@@ -51,6 +51,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -70,6 +71,15 @@ const (
 	// ReasonInvalidWorkspaceBinding indicates that a Pipeline expects a workspace but a
 	// PipelineRun has provided an invalid binding.
 	ReasonInvalidWorkspaceBinding = "InvalidWorkspaceBindings"
+	// ReasonConcurrentWorkspaceWrites indicates that PipelineTasks that can run at the same time
+	// can write the same workspace.
+	ReasonConcurrentWorkspaceWrites = "ConcurrentWorkspaceWrites"
+	// ReasonWorkspaceCommitNotCloned indicates that a PipelineTask uses the commit of a workspace
+	// pre-populated from git that was not cloned.
+	ReasonWorkspaceCommitNotCloned = "WorkspaceCommitNotCloned"
+	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
+	// has been passed to a Task that is expecting a non-optional workspace
+	ReasonRequiredWorkspaceMarkedOptional = "RequiredWorkspaceMarkedOptional"
 	// ReasonInvalidServiceAccountMapping indicates that PipelineRun.Spec.ServiceAccountNames defined with a wrong taskName
 	ReasonInvalidServiceAccountMapping = "InvalidServiceAccountMappings"
 	// ReasonParameterTypeMismatch indicates that the reason for the failure status is that
@@ -183,6 +193,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to delete StatefulSet for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.deleteWorkspacePVCs(ctx, pr); err != nil {
+			logger.Errorf("Failed to delete workspace PVCs for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		if err := c.updateTaskRunsStatusDirectly(pr); err != nil {
 			logger.Errorf("Failed to update TaskRun status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
@@ -488,7 +502,33 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
+	if err := validateWorkspaceGitClones(pipelineRunFacts, pr.Spec.Workspaces); err != nil {
+		logger.Errorf("PipelineRun %q doesn't clone the git workspaces correctly: %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidWorkspaceBinding,
+			"PipelineRun %s/%s doesn't bind Pipeline %s/%s's Workspaces correctly: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
+	if err := validateCacheKeyResults(pipelineRunFacts, pr.Spec.Workspaces); err != nil {
+		logger.Errorf("PipelineRun %q doesn't key the cache workspaces correctly: %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidWorkspaceBinding,
+			"PipelineRun %s/%s doesn't bind Pipeline %s/%s's Workspaces correctly: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	if pipelineRunFacts.State.IsBeforeFirstTaskRun() {
+		// Warn when PipelineTasks that can run at the same time write the same shared workspace. Tasks that
+		// write distinct files of the workspace are valid, so the PipelineRun is not failed.
+		// This is only checked before the first TaskRun so that the warning is emitted once.
+		if err := resources.ValidateConcurrentWorkspaceWrites(pipelineRunFacts, pr.Spec.Workspaces); err != nil {
+			logger.Warnf("PipelineRun %q can write workspaces concurrently: %v", pr.Name, err)
+			controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, ReasonConcurrentWorkspaceWrites,
+				"PipelineRun %s/%s can write Pipeline %s/%s's Workspaces concurrently: %s",
+				pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		}
+
 		if pr.HasVolumeClaimTemplate() {
 			// create workspace PVC from template
 			if err = c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, pr.Spec.Workspaces, pr.GetOwnerReference(), pr.Namespace); err != nil {
@@ -500,6 +540,20 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 			}
 		}
 
+		// create or reuse the PVCs of cache workspaces, except those keyed by results that are not produced yet
+		if err = c.pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, pr.Spec.Workspaces, volumeclaim.PipelineRunCacheKeyReplacements(pr), pr.Namespace); err != nil {
+			if volumeclaim.IsCacheTerminating(err) {
+				// retry once the terminating cache is gone
+				logger.Infof("Waiting for the cache PVC of PipelineRun %s: %v", pr.Name, err)
+				return err
+			}
+			logger.Errorf("Failed to create cache PVC for PipelineRun %s: %v", pr.Name, err)
+			pr.Status.MarkFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
+				"Failed to create cache PVC for PipelineRun %s/%s Workspaces correctly: %s",
+				pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		}
+
 		if !c.isAffinityAssistantDisabled(ctx) {
 			// create Affinity Assistant (StatefulSet) so that taskRun pods that share workspace PVC achieve Node Affinity
 			if err = c.createAffinityAssistants(ctx, pr.Spec.Workspaces, pr, pr.Namespace); err != nil {
@@ -584,19 +638,43 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 		nextRprts = append(nextRprts, fnextRprts...)
 	}
 
+	// Replace the commits cloned into the workspaces by the TaskRuns that are done
+	resources.ApplyWorkspaceCommits(nextRprts, pipelineRunFacts.State)
+	if err := resources.ValidateWorkspaceCommits(nextRprts, pipelineRunFacts, pr.Spec.Workspaces); err != nil {
+		logger.Infof("Failed to resolve workspace commit for %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonWorkspaceCommitNotCloned, err.Error())
+		return controller.NewPermanentError(err)
+	}
+
+	// Tasks using a cache keyed by results can only run once the results are produced
+	if err := validateCacheKeysResolved(nextRprts, pipelineRunFacts, pr); err != nil {
+		logger.Infof("Failed to resolve cache key for %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
+		return controller.NewPermanentError(err)
+	}
+
+	// Tasks requiring a workspace can only run if the optional Pipeline workspace bound to it is provided
+	if err := resources.ValidateOptionalWorkspaces(nextRprts, pipelineRunFacts, pr.Spec.Workspaces); err != nil {
+		logger.Errorf("Optional workspace not supported by task: %v", err)
+		pr.Status.MarkFailed(ReasonRequiredWorkspaceMarkedOptional,
+			"PipelineRun %s/%s doesn't bind optional Workspaces that Tasks require: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	for _, rprt := range nextRprts {
 		if rprt == nil || rprt.Skip(pipelineRunFacts) {
 			continue
 		}
 		if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
 			if rprt.IsCustomTask() {
-				rprt.Run, err = c.createRun(ctx, rprt, pr)
+				rprt.Run, err = c.createRun(ctx, rprt, pr, pipelineRunFacts)
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
 					return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
 				}
 			} else {
-				rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr))
+				rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, pipelineRunFacts, as.StorageBasePath(pr))
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 					return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -647,7 +725,7 @@ func (c *Reconciler) updateRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
+func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, facts *resources.PipelineRunFacts, storageBasePath string) (*v1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
 
 	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
@@ -676,6 +754,8 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTaskRunTimeout(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
+			StepOverrides:      taskRunSpec.StepOverrides,
+			SidecarOverrides:   taskRunSpec.SidecarOverrides,
 		}}
 
 	if rprt.ResolvedTaskResources.TaskName != "" {
@@ -685,15 +765,19 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 		tr.Spec.TaskSpec = rprt.ResolvedTaskResources.TaskSpec
 	}
 
-	var pipelinePVCWorkspaceName string
+	if err := c.createCachesKeyedByResults(ctx, pr, rprt, facts); err != nil {
+		return nil, err
+	}
+
+	var usesPVC bool
 	var err error
-	tr.Spec.Workspaces, pipelinePVCWorkspaceName, err = getTaskrunWorkspaces(pr, rprt)
+	tr.Spec.Workspaces, usesPVC, err = getTaskrunWorkspaces(pr, rprt, facts)
 	if err != nil {
 		return nil, err
 	}
 
-	if !c.isAffinityAssistantDisabled(ctx) && pipelinePVCWorkspaceName != "" {
-		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
+	if !c.isAffinityAssistantDisabled(ctx) && usesPVC {
+		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pr.Name)
 	}
 
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
@@ -701,7 +785,19 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
-func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun) (*v1alpha1.Run, error) {
+// deleteWorkspacePVCs deletes the PVCs created from the volumeClaimTemplates of the done PipelineRun
+// according to their retention policy, and records an event for each deleted PVC.
+func (c *Reconciler) deleteWorkspacePVCs(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	succeeded := pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	deleted, err := c.pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, pr.Spec.Workspaces, pr.GetOwnerReference(), pr.Namespace, succeeded)
+	recorder := controller.GetEventRecorder(ctx)
+	for _, claimName := range deleted {
+		recorder.Eventf(pr, corev1.EventTypeNormal, volumeclaim.ReasonDeletedWorkspacePVC, "Deleted PersistentVolumeClaim %s", claimName)
+	}
+	return err
+}
+
+func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, facts *resources.PipelineRunFacts) (*v1alpha1.Run, error) {
 	logger := logging.FromContext(ctx)
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
 	r := &v1alpha1.Run{
@@ -720,48 +816,96 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 		},
 	}
 
-	var pipelinePVCWorkspaceName string
+	if err := c.createCachesKeyedByResults(ctx, pr, rprt, facts); err != nil {
+		return nil, err
+	}
+
+	var usesPVC bool
 	var err error
-	r.Spec.Workspaces, pipelinePVCWorkspaceName, err = getTaskrunWorkspaces(pr, rprt)
+	r.Spec.Workspaces, usesPVC, err = getTaskrunWorkspaces(pr, rprt, facts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Set the affinity assistant annotation in case the custom task creates TaskRuns or Pods
 	// that can take advantage of it.
-	if !c.isAffinityAssistantDisabled(ctx) && pipelinePVCWorkspaceName != "" {
-		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
+	if !c.isAffinityAssistantDisabled(ctx) && usesPVC {
+		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pr.Name)
 	}
 
 	logger.Infof("Creating a new Run object %s", rprt.RunName)
 	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
 }
 
-func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) ([]v1beta1.WorkspaceBinding, string, error) {
+func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask, facts *resources.PipelineRunFacts) ([]v1beta1.WorkspaceBinding, bool, error) {
 	var workspaces []v1beta1.WorkspaceBinding
-	var pipelinePVCWorkspaceName string
+	var usesPVC bool
 	pipelineRunWorkspaces := make(map[string]v1beta1.WorkspaceBinding)
 	for _, binding := range pr.Spec.Workspaces {
 		pipelineRunWorkspaces[binding.Name] = binding
 	}
+	cacheKeyReplacements := getCacheKeyReplacements(pr, facts)
 	for _, ws := range rprt.PipelineTask.Workspaces {
 		taskWorkspaceName, pipelineTaskSubPath, pipelineWorkspaceName := ws.Name, ws.SubPath, ws.Workspace
 		if b, hasBinding := pipelineRunWorkspaces[pipelineWorkspaceName]; hasBinding {
-			if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil {
-				pipelinePVCWorkspaceName = pipelineWorkspaceName
+			if usesPersistentVolumeClaim(b) {
+				usesPVC = true
 			}
-			workspaces = append(workspaces, taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, pr.GetOwnerReference()))
-		} else {
-			return nil, "", fmt.Errorf("expected workspace %q to be provided by pipelinerun for pipeline task %q", pipelineWorkspaceName, rprt.PipelineTask.Name)
+			var binding v1beta1.WorkspaceBinding
+			if b.OCI != nil {
+				binding = getOCIWorkspaceBinding(b, taskWorkspaceName, pipelineTaskSubPath, pr, rprt.PipelineTask.Name, pipelineWorkspaceName, facts)
+			} else {
+				binding = taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, pr, cacheKeyReplacements)
+			}
+			binding.ReadOnly = b.ReadOnly || ws.ReadOnly
+			binding.Snapshot = getTaskRunWorkspaceSnapshot(b.Snapshot, rprt.PipelineTask.Name)
+			// Only the first PipelineTasks using the workspace clone its git repository
+			binding.Git = nil
+			if b.Git != nil && len(getPrecedingWorkspaceUsers(facts, rprt.PipelineTask.Name, pipelineWorkspaceName)) == 0 {
+				binding.Git = b.Git.DeepCopy()
+			}
+			workspaces = append(workspaces, binding)
+		} else if !taskDeclaresOptional(rprt, taskWorkspaceName) {
+			return nil, false, fmt.Errorf("expected workspace %q to be provided by pipelinerun for pipeline task %q", pipelineWorkspaceName, rprt.PipelineTask.Name)
+		}
+	}
+	return workspaces, usesPVC, nil
+}
+
+// getTaskRunWorkspaceSnapshot returns the snapshot configuration of a PipelineRun workspace for the TaskRun of the
+// PipelineTask, or nil if the PipelineTask isn't one of the tasks the snapshots are restricted to.
+func getTaskRunWorkspaceSnapshot(snapshot *v1beta1.WorkspaceSnapshot, pipelineTaskName string) *v1beta1.WorkspaceSnapshot {
+	if snapshot == nil {
+		return nil
+	}
+	if len(snapshot.Tasks) > 0 && !sets.NewString(snapshot.Tasks...).Has(pipelineTaskName) {
+		return nil
+	}
+	s := snapshot.DeepCopy()
+	s.Tasks = nil
+	return s
+}
+
+// taskDeclaresOptional returns true if the Task of the PipelineTask declares the workspace optional, in which case
+// the TaskRun can be created without binding it when the optional Pipeline workspace is not bound.
+func taskDeclaresOptional(rprt *resources.ResolvedPipelineRunTask, taskWorkspaceName string) bool {
+	if rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+		return false
+	}
+	for _, ws := range rprt.ResolvedTaskResources.TaskSpec.Workspaces {
+		if ws.Name == taskWorkspaceName {
+			return ws.Optional
 		}
 	}
-	return workspaces, pipelinePVCWorkspaceName, nil
+	return false
 }
 
 // taskWorkspaceByWorkspaceVolumeSource is returning the WorkspaceBinding with the TaskRun specified name.
-// If the volume source is a volumeClaimTemplate, the template is applied and passed to TaskRun as a persistentVolumeClaim
-func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorkspaceName string, pipelineTaskSubPath string, owner metav1.OwnerReference) v1beta1.WorkspaceBinding {
-	if wb.VolumeClaimTemplate == nil {
+// If the volume source is a volumeClaimTemplate, the template is applied and passed to TaskRun as a persistentVolumeClaim.
+// If the volume source is a cache, the persistentVolumeClaim of the cache for the key resolved with the
+// replacements is passed to the TaskRun.
+func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorkspaceName string, pipelineTaskSubPath string, pr *v1beta1.PipelineRun, cacheKeyReplacements map[string]string) v1beta1.WorkspaceBinding {
+	if wb.VolumeClaimTemplate == nil && wb.Cache == nil {
 		binding := *wb.DeepCopy()
 		binding.Name = taskWorkspaceName
 		binding.SubPath = combinedSubPath(wb.SubPath, pipelineTaskSubPath)
@@ -769,10 +913,11 @@ func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorks
 	}
 
 	// apply template
+	claimName := getClaimName(wb, pr, cacheKeyReplacements)
 	binding := v1beta1.WorkspaceBinding{
 		SubPath: combinedSubPath(wb.SubPath, pipelineTaskSubPath),
 		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-			ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb.VolumeClaimTemplate, wb, owner),
+			ClaimName: claimName,
 		},
 	}
 	binding.Name = taskWorkspaceName
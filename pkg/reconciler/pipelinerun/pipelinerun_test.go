@@ -38,9 +38,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
@@ -48,6 +50,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -77,9 +80,11 @@ var (
 		KubeconfigWriterImage:    "override-with-kubeconfig-writer:latest",
 		ShellImage:               "busybox",
 		GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
+		AwsCLIImage:              "docker.io/amazon/aws-cli",
 		BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+		WorkspaceArtifactImage:   "override-with-workspace-artifact-image:latest",
 	}
 
 	ignoreResourceVersion = cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion")
@@ -574,7 +579,7 @@ func TestReconcile_CustomTask(t *testing.T) {
 					"tekton.dev/pipelineTask": pipelineTaskName,
 				},
 				Annotations: map[string]string{
-					"pipeline.tekton.dev/affinity-assistant": getAffinityAssistantName("pipelinews", pipelineRunName),
+					"pipeline.tekton.dev/affinity-assistant": getAffinityAssistantName(pipelineRunName),
 				},
 			},
 			Spec: v1alpha1.RunSpec{
@@ -740,6 +745,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.Task("a-task-that-needs-a-resource", tb.TaskSpec(
 			tb.TaskResources(tb.TaskResourcesInput("workspace", "git")),
 		), tb.TaskNamespace("foo")),
+		tb.Task("a-task-that-needs-a-workspace", tb.TaskSpec(
+			tb.TaskWorkspace("config", "", "", false),
+		), tb.TaskNamespace("foo")),
 	}
 	ps := []*v1beta1.Pipeline{
 		tb.Pipeline("pipeline-missing-tasks", tb.PipelineNamespace("foo"), tb.PipelineSpec(
@@ -758,6 +766,17 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			tb.PipelineParamSpec("some-param", v1beta1.ParamTypeArray),
 			tb.PipelineTask("some-task", "a-task-that-needs-array-params"))),
 		tb.Pipeline("a-pipeline-with-missing-conditions", tb.PipelineNamespace("foo"), tb.PipelineSpec(tb.PipelineTask("some-task", "a-task-that-exists", tb.PipelineTaskCondition("condition-does-not-exist")))),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-pipeline-with-an-optional-workspace", Namespace: "foo"},
+			Spec: v1beta1.PipelineSpec{
+				Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "config", Optional: true}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name:       "some-task",
+					TaskRef:    &v1beta1.TaskRef{Name: "a-task-that-needs-a-workspace"},
+					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "config", Workspace: "config"}},
+				}},
+			},
+		},
 	}
 
 	for _, tc := range []struct {
@@ -912,6 +931,15 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			"Normal Started",
 			"Warning Failed PipelineRun foo's Pipeline DAG is invalid for finally clause",
 		},
+	}, {
+		name:           "invalid-pipeline-with-optional-workspace-bound-to-required-task-workspace",
+		pipelineRun:    tb.PipelineRun("pipeline-optional-workspace-required-by-task", tb.PipelineRunNamespace("foo"), tb.PipelineRunSpec("a-pipeline-with-an-optional-workspace")),
+		reason:         ReasonRequiredWorkspaceMarkedOptional,
+		permanentError: true,
+		wantEvents: []string{
+			"Normal Started",
+			"Warning Failed PipelineRun foo/pipeline-optional-workspace-required-by-task doesn't bind optional Workspaces that Tasks require",
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			d := test.Data{
@@ -2053,6 +2081,10 @@ func TestGetTaskRunTimeout(t *testing.T) {
 func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 	names.TestingSeed()
 	prName := "test-pipeline-run"
+	stepOverrides := []v1beta1.TaskRunStepOverride{{
+		Name: "hello",
+		Env:  []corev1.EnvVar{{Name: "GREETING", Value: "hi"}},
+	}}
 	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
 	))}
@@ -2068,6 +2100,7 @@ func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 						"workloadtype": "tekton",
 					},
 				},
+				StepOverrides: stepOverrides,
 			}}),
 		),
 	)}
@@ -2111,8 +2144,10 @@ func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 		),
 	)
 
+	expectedTaskRun.Spec.StepOverrides = stepOverrides
+
 	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
-		t.Errorf("expected to see propagated custom ServiceAccountName and PodTemplate in TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+		t.Errorf("expected to see propagated custom ServiceAccountName, PodTemplate and StepOverrides in TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
 	}
 }
 
@@ -2672,8 +2707,8 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 }
 
 // TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
-// an Affinity Assistant StatefulSet is created for each PVC workspace and
-// that the Affinity Assistant names is propagated to TaskRuns.
+// a single Affinity Assistant StatefulSet mounting all PVC workspaces is created and
+// that the Affinity Assistant name is propagated to TaskRuns.
 func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
 	workspaceName := "ws1"
 	workspaceName2 := "ws2"
@@ -2706,31 +2741,34 @@ func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
 	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
 
 	// Check that the expected StatefulSet was created
-	stsNames := make([]string, 0)
+	var statefulSets []*appsv1.StatefulSet
 	for _, a := range clients.Kube.Actions() {
 		if ca, ok := a.(ktesting.CreateAction); ok {
 			obj := ca.GetObject()
 			if sts, ok := obj.(*appsv1.StatefulSet); ok {
-				stsNames = append(stsNames, sts.Name)
+				statefulSets = append(statefulSets, sts)
 			}
 		}
 	}
 
-	if len(stsNames) != 2 {
-		t.Fatalf("expected one StatefulSet created. %d was created", len(stsNames))
+	if len(statefulSets) != 1 {
+		t.Fatalf("expected one StatefulSet created. %d was created", len(statefulSets))
+	}
+
+	expectedAffinityAssistantName := getAffinityAssistantName(pipelineRunName)
+	if statefulSets[0].Name != expectedAffinityAssistantName {
+		t.Errorf("unexpected StatefulSet created, named %s", statefulSets[0].Name)
 	}
 
-	expectedAffinityAssistantName1 := getAffinityAssistantName(workspaceName, pipelineRunName)
-	expectedAffinityAssistantName2 := getAffinityAssistantName(workspaceName2, pipelineRunName)
-	expectedAffinityAssistantStsNames := make(map[string]bool)
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName1] = true
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName2] = true
-	for _, stsName := range stsNames {
-		_, found := expectedAffinityAssistantStsNames[stsName]
-		if !found {
-			t.Errorf("unexpected StatefulSet created, named %s", stsName)
+	var claimNames []string
+	for _, v := range statefulSets[0].Spec.Template.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			claimNames = append(claimNames, v.PersistentVolumeClaim.ClaimName)
 		}
 	}
+	if len(claimNames) != 2 {
+		t.Errorf("expected the StatefulSet to mount both PersistentVolumeClaims, got %v", claimNames)
+	}
 
 	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
 	if err != nil {
@@ -2747,8 +2785,8 @@ func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
 			propagatedAffinityAssistantName := tr.Annotations["pipeline.tekton.dev/affinity-assistant"]
 			if ws.PersistentVolumeClaim != nil {
 
-				if propagatedAffinityAssistantName != expectedAffinityAssistantName1 && propagatedAffinityAssistantName != expectedAffinityAssistantName2 {
-					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s or %s, got %s", expectedAffinityAssistantName1, expectedAffinityAssistantName2, propagatedAffinityAssistantName)
+				if propagatedAffinityAssistantName != expectedAffinityAssistantName {
+					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s, got %s", expectedAffinityAssistantName, propagatedAffinityAssistantName)
 				}
 				taskRunsWithPropagatedAffinityAssistantName++
 			}
@@ -2762,7 +2800,7 @@ func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
 	}
 
 	if taskRunsWithPropagatedAffinityAssistantName != 2 {
-		t.Errorf("expected only one of two TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
+		t.Errorf("expected two of three TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
 	}
 
 	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
@@ -2772,6 +2810,122 @@ func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
 
 // TestReconcileWithVolumeClaimTemplateWorkspace tests that given a pipeline with volumeClaimTemplate workspace,
 // a PVC is created and that the workspace appears as a PersistentVolumeClaim workspace for TaskRuns.
+// TestReconcileWithConcurrentWorkspaceWrites tests that PipelineTasks that can write a shared
+// workspace at the same time only emit a warning, and the PipelineRun keeps running.
+func TestReconcileWithConcurrentWorkspaceWrites(t *testing.T) {
+	workspaceName := "ws1"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineWorkspaceDeclaration(workspaceName),
+	))}
+
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, "myclaim", ""))),
+	}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"), tb.TaskSpec(
+		tb.TaskWorkspace("taskWorkspaceName", "", "", false),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning ConcurrentWorkspaceWrites PipelineRun foo/test-pipeline-run can write Pipeline foo/test-pipeline's Workspaces concurrently",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, wantEvents, false)
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 2 {
+		t.Errorf("Expected the two PipelineTasks to run, got %d TaskRuns", len(taskRuns.Items))
+	}
+}
+
+// TestReconcileWithOptionalWorkspace tests that a PipelineTask guarded by $(workspaces.<name>.bound) can bind an
+// optional Pipeline workspace to a required Task workspace: it runs when the PipelineRun binds the workspace, and
+// is skipped otherwise.
+func TestReconcileWithOptionalWorkspace(t *testing.T) {
+	ps := []*v1beta1.Pipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "foo"},
+		Spec: v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "lint-config", Optional: true}},
+			Tasks: []v1beta1.PipelineTask{{
+				Name:       "lint",
+				TaskRef:    &v1beta1.TaskRef{Name: "lint"},
+				Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "config", Workspace: "lint-config"}},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "$(workspaces.lint-config.bound)",
+					Operator: selection.In,
+					Values:   []string{"true"},
+				}},
+			}},
+		},
+	}}
+	ts := []*v1beta1.Task{tb.Task("lint", tb.TaskNamespace("foo"), tb.TaskSpec(
+		tb.TaskWorkspace("config", "", "", false),
+	))}
+
+	for _, tc := range []struct {
+		name         string
+		pipelineRun  *v1beta1.PipelineRun
+		wantEvents   []string
+		wantTaskRuns int
+	}{{
+		name: "optional workspace bound",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-bound", tb.PipelineRunNamespace("foo"),
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunWorkspaceBindingEmptyDir("lint-config"))),
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+		},
+		wantTaskRuns: 1,
+	}, {
+		name:        "optional workspace not bound",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-unbound", tb.PipelineRunNamespace("foo"), tb.PipelineRunSpec("test-pipeline")),
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Succeeded Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Skipped: 1",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{tc.pipelineRun},
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", tc.pipelineRun.Name, tc.wantEvents, false)
+
+			if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+				t.Errorf("Expected PipelineRun not to fail, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != tc.wantTaskRuns {
+				t.Errorf("Expected %d TaskRuns, got %d", tc.wantTaskRuns, len(taskRuns.Items))
+			}
+		})
+	}
+}
+
 func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
 	workspaceName := "ws1"
 	claimName := "myclaim"
@@ -2839,6 +2993,370 @@ func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
 	}
 }
 
+// TestReconcileDeletesVolumeClaimTemplatePVCOnSuccess tests that the PVC created from a volumeClaimTemplate
+// workspace with the deleteOnSuccess retention policy is deleted once the PipelineRun has succeeded.
+func TestReconcileDeletesVolumeClaimTemplatePVCOnSuccess(t *testing.T) {
+	workspaceName := "ws1"
+	claimName := "myclaim"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", workspaceName, "")),
+		tb.PipelineWorkspaceDeclaration(workspaceName),
+	))}
+
+	pr := tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunWorkspaceBindingVolumeClaimTemplate(workspaceName, claimName, "")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.PipelineRunReasonSuccessful.String(),
+			Message: "All Tasks have completed executing",
+		})),
+	)
+	pr.Spec.Workspaces[0].VolumeClaimRetentionPolicy = v1beta1.VolumeClaimDeleteOnSuccess
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	expectedPVCName := fmt.Sprintf("%s-%s", claimName, "cab465d09a")
+	if _, err := prt.TestAssets.Clients.Kube.CoreV1().PersistentVolumeClaims("foo").Create(prt.TestAssets.Ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: expectedPVCName, Namespace: "foo"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantEvents := []string{
+		"Normal Succeeded All Tasks have completed executing",
+		"Normal DeletedWorkspacePVC Deleted PersistentVolumeClaim " + expectedPVCName,
+	}
+	_, clients := prt.reconcileRun("foo", pipelineRunName, wantEvents, false)
+
+	if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected PVC %s to be deleted, got: %v", expectedPVCName, err)
+	}
+}
+
+// TestReconcileWithCacheWorkspace tests that the PVC of a cache workspace is created for the key resolved
+// with the params of the PipelineRun and that it is passed to the TaskRuns.
+func TestReconcileWithCacheWorkspace(t *testing.T) {
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", "cache", "")),
+		tb.PipelineWorkspaceDeclaration("cache"),
+		tb.PipelineParamSpec("go-sum-hash", v1beta1.ParamTypeString),
+	))}
+
+	pr := tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunParam("go-sum-hash", "abc")))
+	pr.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+		Name: "cache",
+		Cache: &v1beta1.WorkspaceCache{
+			Key: "$(params.go-sum-hash)",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "go-modules"},
+			},
+		},
+	}}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	expectedPVCName := volumeclaim.GetCachePersistentVolumeClaimName(pr.Spec.Workspaces[0], volumeclaim.PipelineRunCacheKeyReplacements(pr))
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the cache PVC %s to be created: %v", expectedPVCName, err)
+	}
+	if len(pvc.OwnerReferences) != 0 {
+		t.Errorf("expected the cache PVC not to be owned by the PipelineRun, got %v", pvc.OwnerReferences)
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("expected one TaskRun created. %d was created", len(taskRuns.Items))
+	}
+	ws := taskRuns.Items[0].Spec.Workspaces
+	if len(ws) != 1 || ws[0].PersistentVolumeClaim == nil || ws[0].PersistentVolumeClaim.ClaimName != expectedPVCName {
+		t.Errorf("expected the TaskRun to be bound to the cache PVC %s, got %v", expectedPVCName, ws)
+	}
+}
+
+// TestReconcileWithCacheWorkspaceKeyedByResults tests that the PVC of a cache workspace whose key references
+// task results is only created once the results are produced, and that it is passed to the TaskRuns using it.
+func TestReconcileWithCacheWorkspaceKeyedByResults(t *testing.T) {
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hash", "hello-world"),
+		tb.PipelineTask("build", "hello-world", tb.RunAfter("hash"), tb.PipelineTaskWorkspaceBinding("taskWorkspaceName", "cache", "")),
+		tb.PipelineWorkspaceDeclaration("cache"),
+	))}
+	pr := tb.PipelineRun(pipelineRunName, tb.PipelineRunNamespace("foo"), tb.PipelineRunSpec("test-pipeline"))
+	pr.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+		Name: "cache",
+		Cache: &v1beta1.WorkspaceCache{
+			Key: "go-$(tasks.hash.results.go-sum)",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "go-modules"},
+			},
+		},
+	}}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	hashTaskRun := tb.TaskRun(pipelineRunName+"-hash",
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunOwnerReference("PipelineRun", pipelineRunName,
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", pipelineRunName),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "hash"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+	)
+
+	t.Run("results not produced yet", func(t *testing.T) {
+		d := test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{pr},
+			Pipelines:    ps,
+			Tasks:        ts,
+		}
+		prt := NewPipelineRunTest(d, t)
+		defer prt.Cancel()
+
+		_, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+		pvcs, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error when listing PVCs: %v", err)
+		}
+		if len(pvcs.Items) != 0 {
+			t.Errorf("expected no cache PVC before the results of its key are produced, got %v", pvcs.Items)
+		}
+	})
+
+	t.Run("results produced", func(t *testing.T) {
+		tr := hashTaskRun.DeepCopy()
+		tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+		tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "go-sum", Value: "abc"}}
+		d := test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{pr},
+			Pipelines:    ps,
+			Tasks:        ts,
+			TaskRuns:     []*v1beta1.TaskRun{tr},
+		}
+		prt := NewPipelineRunTest(d, t)
+		defer prt.Cancel()
+
+		_, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+		expectedPVCName := volumeclaim.GetCachePersistentVolumeClaimName(pr.Spec.Workspaces[0], map[string]string{"tasks.hash.results.go-sum": "abc"})
+		if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{}); err != nil {
+			t.Fatalf("expected the cache PVC %s to be created: %v", expectedPVCName, err)
+		}
+		taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: "tekton.dev/pipelineTask=build",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		}
+		if len(taskRuns.Items) != 1 {
+			t.Fatalf("expected one TaskRun created for the build task, %d were created", len(taskRuns.Items))
+		}
+		ws := taskRuns.Items[0].Spec.Workspaces
+		if len(ws) != 1 || ws[0].PersistentVolumeClaim == nil || ws[0].PersistentVolumeClaim.ClaimName != expectedPVCName {
+			t.Errorf("expected the TaskRun to be bound to the cache PVC %s, got %v", expectedPVCName, ws)
+		}
+	})
+}
+
+// TestGetTaskrunWorkspacesWithGit tests that only the TaskRuns of the first PipelineTasks using a workspace
+// pre-populated from a git repository clone the repository.
+func TestGetTaskrunWorkspacesWithGit(t *testing.T) {
+	tasks := []v1beta1.PipelineTask{
+		workspaceTask("fetch"),
+		workspaceTask("build", "fetch"),
+	}
+	g, err := dag.Build(v1beta1.PipelineTaskList(tasks), v1beta1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	facts := &resources.PipelineRunFacts{TasksGraph: g}
+	gitSource := &v1beta1.WorkspaceGit{URL: "https://github.com/tektoncd/pipeline", Revision: "main"}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-pvc",
+				},
+				Git: gitSource,
+			}},
+		},
+	}
+
+	for _, tc := range []struct {
+		pipelineTask int
+		wantGit      *v1beta1.WorkspaceGit
+	}{{
+		pipelineTask: 0,
+		wantGit:      gitSource,
+	}, {
+		pipelineTask: 1,
+	}} {
+		t.Run(tasks[tc.pipelineTask].Name, func(t *testing.T) {
+			rprt := &resources.ResolvedPipelineRunTask{PipelineTask: &tasks[tc.pipelineTask]}
+			ws, _, err := getTaskrunWorkspaces(pr, rprt, facts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ws) != 1 {
+				t.Fatalf("expected one workspace, got %v", ws)
+			}
+			if d := cmp.Diff(tc.wantGit, ws[0].Git); d != "" {
+				t.Errorf("unexpected git source %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestGetTaskrunWorkspacesWithReadOnly tests that a workspace bound readOnly in a PipelineTask is bound
+// readOnly in its TaskRun.
+func TestGetTaskrunWorkspacesWithReadOnly(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-pvc",
+				},
+			}},
+		},
+	}
+	for _, readOnly := range []bool{false, true} {
+		pt := v1beta1.PipelineTask{
+			Name: "lint",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+				Name:      "source",
+				Workspace: "source",
+				ReadOnly:  readOnly,
+			}},
+		}
+		ws, _, err := getTaskrunWorkspaces(pr, &resources.ResolvedPipelineRunTask{PipelineTask: &pt}, &resources.PipelineRunFacts{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ws) != 1 || ws[0].ReadOnly != readOnly {
+			t.Errorf("expected the workspace to be bound with readOnly %t, got %v", readOnly, ws)
+		}
+	}
+}
+
+// TestGetTaskrunWorkspacesWithOptionalWorkspace tests that an optional Pipeline workspace that is not bound by the
+// PipelineRun is omitted from the TaskRuns of the Tasks that declare it optional.
+func TestGetTaskrunWorkspacesWithOptionalWorkspace(t *testing.T) {
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr"}}
+	pt := v1beta1.PipelineTask{
+		Name: "lint",
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+			Name:      "config",
+			Workspace: "lint-config",
+		}},
+	}
+	for _, tc := range []struct {
+		name     string
+		optional bool
+		wantErr  bool
+	}{{
+		name:     "task declares the workspace optional",
+		optional: true,
+	}, {
+		name:    "task requires the workspace",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := &resources.ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				ResolvedTaskResources: &taskrunresources.ResolvedTaskResources{TaskSpec: &v1beta1.TaskSpec{
+					Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "config", Optional: tc.optional}},
+				}},
+			}
+			ws, _, err := getTaskrunWorkspaces(pr, rprt, &resources.PipelineRunFacts{})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ws) != 0 {
+				t.Errorf("expected no workspace to be bound, got %v", ws)
+			}
+		})
+	}
+}
+
+// TestGetTaskrunWorkspacesWithSnapshot tests that the snapshot configuration of a PipelineRun workspace is only
+// passed to the TaskRuns of the PipelineTasks the snapshots are restricted to.
+func TestGetTaskrunWorkspacesWithSnapshot(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "pvc"},
+				},
+				Snapshot: &v1beta1.WorkspaceSnapshot{
+					When:  v1beta1.WorkspaceSnapshotAlways,
+					Tasks: []string{"build"},
+				},
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		pipelineTask string
+		want         *v1beta1.WorkspaceSnapshot
+	}{{
+		pipelineTask: "build",
+		want:         &v1beta1.WorkspaceSnapshot{When: v1beta1.WorkspaceSnapshotAlways},
+	}, {
+		pipelineTask: "lint",
+	}} {
+		t.Run(tc.pipelineTask, func(t *testing.T) {
+			pt := workspaceTask(tc.pipelineTask)
+			ws, _, err := getTaskrunWorkspaces(pr, &resources.ResolvedPipelineRunTask{PipelineTask: &pt}, &resources.PipelineRunFacts{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(ws) != 1 {
+				t.Fatalf("expected one workspace, got %v", ws)
+			}
+			if d := cmp.Diff(tc.want, ws[0].Snapshot); d != "" {
+				t.Errorf("unexpected snapshot %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
 // multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
 func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
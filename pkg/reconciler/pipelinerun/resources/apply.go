@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 )
@@ -98,6 +99,81 @@ func ApplyPipelineTaskContext(state PipelineRunState, replacements map[string]st
 	}
 }
 
+// ApplyWorkspaceCommits replaces the $(workspaces.<name>.commit) variables in the params and when expressions
+// of the targets with the commits cloned into the pipeline workspaces by the TaskRuns of the state.
+func ApplyWorkspaceCommits(targets PipelineRunState, state PipelineRunState) {
+	replacements := map[string]string{}
+	for _, rprt := range state {
+		if rprt.PipelineTask == nil || rprt.TaskRun == nil {
+			continue
+		}
+		for _, ws := range rprt.PipelineTask.Workspaces {
+			for _, r := range rprt.TaskRun.Status.ResourcesResult {
+				if r.ResourceName == resources.GetWorkspaceGitResourceName(ws.Name) && r.Key == "commit" {
+					replacements[fmt.Sprintf("workspaces.%s.commit", ws.Workspace)] = r.Value
+				}
+			}
+		}
+	}
+	if len(replacements) == 0 {
+		return
+	}
+	for _, rprt := range targets {
+		if rprt.PipelineTask != nil {
+			pipelineTask := rprt.PipelineTask.DeepCopy()
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
+			rprt.PipelineTask = pipelineTask
+		}
+	}
+}
+
+// ValidateWorkspaceCommits validates that the targets that are not skipped don't use the commit of a workspace
+// through $(workspaces.<name>.commit) that was not cloned, e.g. because the PipelineTask cloning it was skipped.
+// It must be called after ApplyWorkspaceCommits.
+func ValidateWorkspaceCommits(targets PipelineRunState, facts *PipelineRunFacts, workspaces []v1beta1.WorkspaceBinding) error {
+	for _, wb := range workspaces {
+		if wb.Git == nil {
+			continue
+		}
+		commit := fmt.Sprintf("$(workspaces.%s.commit)", wb.Name)
+		for _, rprt := range targets {
+			if rprt.PipelineTask == nil || rprt.Skip(facts) {
+				continue
+			}
+			if PipelineTaskReferences(rprt.PipelineTask, commit) {
+				return fmt.Errorf("pipeline task %q uses %s but the git repository of workspace %q was not cloned", rprt.PipelineTask.Name, commit, wb.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// PipelineTaskReferences returns true if the params or when expressions of the PipelineTask contain the expression.
+func PipelineTaskReferences(pt *v1beta1.PipelineTask, expression string) bool {
+	for _, p := range pt.Params {
+		if strings.Contains(p.Value.StringVal, expression) {
+			return true
+		}
+		for _, v := range p.Value.ArrayVal {
+			if strings.Contains(v, expression) {
+				return true
+			}
+		}
+	}
+	for _, we := range pt.WhenExpressions {
+		if strings.Contains(we.Input, expression) {
+			return true
+		}
+		for _, v := range we.Values {
+			if strings.Contains(v, expression) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ApplyWorkspaces replaces workspace variables in the given pipeline spec with their
 // concrete values.
 func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
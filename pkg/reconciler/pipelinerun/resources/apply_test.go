@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -921,3 +922,109 @@ func TestApplyTaskRunContext(t *testing.T) {
 		t.Fatalf("ApplyTaskRunContext() %s", diff.PrintWantGot(d))
 	}
 }
+
+func TestApplyWorkspaceCommits(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:       "fetch",
+			TaskRef:    &v1beta1.TaskRef{Name: "task"},
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "output", Workspace: "source"}},
+		},
+		TaskRun: &v1beta1.TaskRun{
+			Status: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					ResourcesResult: []v1beta1.PipelineResourceResult{{
+						Key:          "commit",
+						Value:        "abc123",
+						ResourceName: "workspaces.output",
+					}, {
+						Key:          "commit",
+						Value:        "def456",
+						ResourceName: "output",
+					}},
+				},
+			},
+		},
+	}}
+	targets := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewArrayOrString("$(workspaces.source.commit)"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(workspaces.source.commit)",
+				Operator: selection.NotIn,
+				Values:   []string{""},
+			}},
+		},
+	}}
+	expectedTargets := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewArrayOrString("abc123"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "abc123",
+				Operator: selection.NotIn,
+				Values:   []string{""},
+			}},
+		},
+	}}
+	ApplyWorkspaceCommits(targets, state)
+	if d := cmp.Diff(expectedTargets, targets); d != "" {
+		t.Fatalf("ApplyWorkspaceCommits() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateWorkspaceCommits(t *testing.T) {
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "source",
+		Git:  &v1beta1.WorkspaceGit{URL: "https://github.com/tektoncd/pipeline"},
+	}}
+	resolved := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewArrayOrString("abc123"),
+			}},
+		},
+	}}
+	if err := ValidateWorkspaceCommits(resolved, factsFromState(t, resolved), workspaces); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	unresolved := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: []v1beta1.Param{{
+				Name:  "revisions",
+				Value: *v1beta1.NewArrayOrString("main", "$(workspaces.source.commit)"),
+			}},
+		},
+	}}
+	if err := ValidateWorkspaceCommits(unresolved, factsFromState(t, unresolved), workspaces); err == nil {
+		t.Errorf("expected an error for a commit that was not cloned")
+	}
+}
+
+func factsFromState(t *testing.T, state PipelineRunState) *PipelineRunFacts {
+	t.Helper()
+	d, err := dagFromState(state)
+	if err != nil {
+		t.Fatalf("Could not get a dag from the state %#v: %v", state, err)
+	}
+	return &PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+	}
+}
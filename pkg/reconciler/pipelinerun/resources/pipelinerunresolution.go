@@ -19,7 +19,9 @@ package resources
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -29,7 +31,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -279,9 +283,97 @@ func ValidateWorkspaceBindings(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun)
 			return fmt.Errorf("pipeline requires workspace with name %q be provided by pipelinerun", ws.Name)
 		}
 	}
+	return volumeclaim.ValidateCacheKeys(pr.Spec.Workspaces, p.Params)
+}
+
+// ValidateOptionalWorkspaces validates that the targets that are not skipped don't bind an optional Workspace of
+// the Pipeline that the PipelineRun doesn't provide to a required Task workspace, so that Tasks requiring a workspace
+// never run without it. Pipeline workspaces the PipelineRun doesn't provide are optional, since the required ones are
+// validated by ValidateWorkspaceBindings. Custom tasks are ignored, as their workspaces are unknown.
+func ValidateOptionalWorkspaces(targets PipelineRunState, facts *PipelineRunFacts, workspaces []v1beta1.WorkspaceBinding) error {
+	bound := sets.NewString()
+	for _, wb := range workspaces {
+		bound.Insert(wb.Name)
+	}
+	for _, rprt := range targets {
+		if rprt.PipelineTask == nil || rprt.IsCustomTask() || rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+			continue
+		}
+		if rprt.Skip(facts) {
+			continue
+		}
+		for _, ws := range rprt.PipelineTask.Workspaces {
+			if bound.Has(ws.Workspace) {
+				continue
+			}
+			for _, declared := range rprt.ResolvedTaskResources.TaskSpec.Workspaces {
+				if declared.Name == ws.Name && !declared.Optional {
+					return fmt.Errorf("pipeline workspace %q is not bound but pipeline task %q binds it to the required workspace %q", ws.Workspace, rprt.PipelineTask.Name, ws.Name)
+				}
+			}
+		}
+	}
 	return nil
 }
 
+// ValidateConcurrentWorkspaceWrites validates that no two PipelineTasks that can run at the same time can write
+// the same directory of a workspace bound to a PersistentVolumeClaim. A PipelineTask can write a workspace when
+// its Task declares it writable and it doesn't bind it readOnly. Custom tasks are ignored, as their workspaces are unknown.
+func ValidateConcurrentWorkspaceWrites(facts *PipelineRunFacts, workspaces []v1beta1.WorkspaceBinding) error {
+	shared := sets.NewString()
+	for _, wb := range workspaces {
+		if wb.PersistentVolumeClaim != nil || wb.VolumeClaimTemplate != nil || wb.Cache != nil {
+			shared.Insert(wb.Name)
+		}
+	}
+
+	type writer struct {
+		pipelineTask string
+		subPath      string
+	}
+	writers := map[string][]writer{}
+	for _, rprt := range facts.State {
+		if rprt.IsCustomTask() || rprt.ResolvedTaskResources == nil || rprt.ResolvedTaskResources.TaskSpec == nil {
+			continue
+		}
+		for _, ws := range rprt.PipelineTask.Workspaces {
+			if !shared.Has(ws.Workspace) || ws.ReadOnly || !declaresWritable(rprt.ResolvedTaskResources.TaskSpec, ws.Name) {
+				continue
+			}
+			writers[ws.Workspace] = append(writers[ws.Workspace], writer{pipelineTask: rprt.PipelineTask.Name, subPath: ws.SubPath})
+		}
+	}
+
+	for _, name := range shared.List() {
+		w := writers[name]
+		for i := range w {
+			for j := i + 1; j < len(w); j++ {
+				if facts.canRunConcurrently(w[i].pipelineTask, w[j].pipelineTask) && subPathsOverlap(w[i].subPath, w[j].subPath) {
+					return fmt.Errorf("pipeline tasks %q and %q can write workspace %q at the same time: add a runAfter ordering between them, or bind the workspace readOnly in one of them",
+						w[i].pipelineTask, w[j].pipelineTask, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// declaresWritable returns true if the Task declares the workspace and doesn't declare it readOnly.
+func declaresWritable(ts *v1beta1.TaskSpec, workspaceName string) bool {
+	for _, w := range ts.Workspaces {
+		if w.Name == workspaceName {
+			return !w.ReadOnly
+		}
+	}
+	return false
+}
+
+// subPathsOverlap returns true if one of the subPaths of a workspace contains the other.
+func subPathsOverlap(a, b string) bool {
+	a, b = filepath.Clean("/"+a), filepath.Clean("/"+b)
+	return a == b || strings.HasPrefix(a, strings.TrimSuffix(b, "/")+"/") || strings.HasPrefix(b, strings.TrimSuffix(a, "/")+"/")
+}
+
 // ValidateTaskRunSpecs that the TaskRunSpecs defined by a PipelineRun are correct.
 func ValidateTaskRunSpecs(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
 	pipelineTasks := make(map[string]string)
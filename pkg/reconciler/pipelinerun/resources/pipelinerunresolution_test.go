@@ -1961,10 +1961,215 @@ func TestValidateWorkspaceBindingsWithInvalidWorkspaces(t *testing.T) {
 				Workspaces: []v1beta1.WorkspaceBinding{},
 			},
 		},
+	}, {
+		name: "cache key referencing an undeclared param",
+		spec: &v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{{Name: "go-sum-hash", Type: v1beta1.ParamTypeString}},
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+			}},
+		},
+		run: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name: "foo",
+					Cache: &v1beta1.WorkspaceCache{
+						Key:                 "go-$(params.go-sum)",
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+					},
+				}},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := ValidateWorkspaceBindings(tc.spec, tc.run); err == nil {
-				t.Fatalf("Expected error indicating `foo` workspace was not provided but got no error")
+				t.Fatalf("Expected error validating the workspaces bound to the PipelineRun but got no error")
+			}
+		})
+	}
+}
+
+func TestValidateConcurrentWorkspaceWrites(t *testing.T) {
+	writer := &resources.ResolvedTaskResources{TaskSpec: &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+	}}
+	reader := &resources.ResolvedTaskResources{TaskSpec: &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source", ReadOnly: true}},
+	}}
+	pvc := []v1beta1.WorkspaceBinding{{
+		Name:                  "ws",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+	}}
+	pipelineTask := func(name, subPath string, readOnly bool, runAfter ...string) v1beta1.PipelineTask {
+		return v1beta1.PipelineTask{
+			Name:       name,
+			RunAfter:   runAfter,
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "ws", SubPath: subPath, ReadOnly: readOnly}},
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		tasks      []v1beta1.PipelineTask
+		finally    []v1beta1.PipelineTask
+		rtr        map[string]*resources.ResolvedTaskResources
+		workspaces []v1beta1.WorkspaceBinding
+		wantErr    bool
+	}{{
+		name:       "writers ordered by runAfter",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", false), pipelineTask("b", "", false, "a")},
+		workspaces: pvc,
+	}, {
+		name:       "concurrent writers",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", false), pipelineTask("b", "", false)},
+		workspaces: pvc,
+		wantErr:    true,
+	}, {
+		name:       "concurrent writers of nested subPaths",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "src", false), pipelineTask("b", "src/vendor", false)},
+		workspaces: pvc,
+		wantErr:    true,
+	}, {
+		name:       "concurrent writers of distinct subPaths",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "src", false), pipelineTask("b", "srcs", false)},
+		workspaces: pvc,
+	}, {
+		name:       "concurrent writer and readOnly binding",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", false), pipelineTask("b", "", true)},
+		workspaces: pvc,
+	}, {
+		name:       "concurrent writer and readOnly declaration",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", false), pipelineTask("b", "", false)},
+		rtr:        map[string]*resources.ResolvedTaskResources{"b": reader},
+		workspaces: pvc,
+	}, {
+		name:  "concurrent writers of an emptyDir",
+		tasks: []v1beta1.PipelineTask{pipelineTask("a", "", false), pipelineTask("b", "", false)},
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name:     "ws",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+	}, {
+		name:       "writers in tasks and finally",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", false)},
+		finally:    []v1beta1.PipelineTask{pipelineTask("b", "", false)},
+		workspaces: pvc,
+	}, {
+		name:       "concurrent writers in finally",
+		tasks:      []v1beta1.PipelineTask{pipelineTask("a", "", true)},
+		finally:    []v1beta1.PipelineTask{pipelineTask("b", "", false), pipelineTask("c", "", false)},
+		workspaces: pvc,
+		wantErr:    true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList(tc.tasks), v1beta1.PipelineTaskList(tc.tasks).Deps())
+			if err != nil {
+				t.Fatal(err)
+			}
+			dfinally, err := dag.Build(v1beta1.PipelineTaskList(tc.finally), map[string][]string{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			facts := &PipelineRunFacts{TasksGraph: d, FinalTasksGraph: dfinally}
+			all := append(append([]v1beta1.PipelineTask{}, tc.tasks...), tc.finally...)
+			for i := range all {
+				rtr := writer
+				if r, ok := tc.rtr[all[i].Name]; ok {
+					rtr = r
+				}
+				facts.State = append(facts.State, &ResolvedPipelineRunTask{PipelineTask: &all[i], ResolvedTaskResources: rtr})
+			}
+
+			err = ValidateConcurrentWorkspaceWrites(facts, tc.workspaces)
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateOptionalWorkspaces(t *testing.T) {
+	sourceBinding := v1beta1.WorkspaceBinding{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	configBinding := v1beta1.WorkspaceBinding{Name: "lint-config", EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	pipelineTask := v1beta1.PipelineTask{
+		Name: "lint",
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+			Name:      "source",
+			Workspace: "source",
+		}, {
+			Name:      "config",
+			Workspace: "lint-config",
+		}},
+	}
+	skippedPipelineTask := *pipelineTask.DeepCopy()
+	skippedPipelineTask.WhenExpressions = v1beta1.WhenExpressions{{
+		Input:    "false",
+		Operator: selection.In,
+		Values:   []string{"true"},
+	}}
+	requiredConfig := &resources.ResolvedTaskResources{TaskSpec: &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}, {Name: "config"}},
+	}}
+	for _, tc := range []struct {
+		name       string
+		rprt       *ResolvedPipelineRunTask
+		workspaces []v1beta1.WorkspaceBinding
+		wantErr    bool
+	}{{
+		name: "unbound workspace bound to an optional task workspace",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask: &pipelineTask,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{TaskSpec: &v1beta1.TaskSpec{
+				Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}, {Name: "config", Optional: true}},
+			}},
+		},
+		workspaces: []v1beta1.WorkspaceBinding{sourceBinding},
+	}, {
+		name: "unbound workspace bound to a required task workspace",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask:          &pipelineTask,
+			ResolvedTaskResources: requiredConfig,
+		},
+		workspaces: []v1beta1.WorkspaceBinding{sourceBinding},
+		wantErr:    true,
+	}, {
+		name: "bound optional workspace bound to a required task workspace",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask:          &pipelineTask,
+			ResolvedTaskResources: requiredConfig,
+		},
+		workspaces: []v1beta1.WorkspaceBinding{sourceBinding, configBinding},
+	}, {
+		name: "unbound workspace bound to a required task workspace of a skipped task",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask:          &skippedPipelineTask,
+			ResolvedTaskResources: requiredConfig,
+		},
+		workspaces: []v1beta1.WorkspaceBinding{sourceBinding},
+	}, {
+		name: "custom task",
+		rprt: &ResolvedPipelineRunTask{
+			PipelineTask: &pipelineTask,
+			CustomTask:   true,
+		},
+		workspaces: []v1beta1.WorkspaceBinding{sourceBinding},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{tc.rprt}
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Could not get a dag from the state %#v: %v", state, err)
+			}
+			facts := &PipelineRunFacts{State: state, TasksGraph: d, FinalTasksGraph: &dag.Graph{}}
+			err = ValidateOptionalWorkspaces(state, facts, tc.workspaces)
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
 			}
 		})
 	}
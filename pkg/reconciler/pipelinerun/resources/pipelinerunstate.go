@@ -489,6 +489,18 @@ func (facts *PipelineRunFacts) isFinalTask(pipelineTaskName string) bool {
 	return false
 }
 
+// canRunConcurrently returns true if the PipelineTasks can run at the same time: two DAG tasks that don't
+// precede one another, or two finally tasks.
+func (facts *PipelineRunFacts) canRunConcurrently(a, b string) bool {
+	switch {
+	case facts.isDAGTask(a) && facts.isDAGTask(b):
+		return !dag.GetAncestors(facts.TasksGraph, a).Has(b) && !dag.GetAncestors(facts.TasksGraph, b).Has(a)
+	case facts.FinalTasksGraph != nil && facts.isFinalTask(a) && facts.isFinalTask(b):
+		return true
+	}
+	return false
+}
+
 // Check if a PipelineTask belongs to the specified Graph
 func isTaskInGraph(pipelineTaskName string, d *dag.Graph) bool {
 	if _, ok := d.Nodes[pipelineTaskName]; ok {
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// getOCIWorkspaceBinding returns the binding of a TaskRun to a pipeline workspace whose content is handed off
+// through OCI artifacts. The TaskRun pulls the artifacts pushed by the closest PipelineTasks that precede it and
+// use the same pipeline workspace, and pushes its own artifact tagged with the PipelineRun and PipelineTask names.
+func getOCIWorkspaceBinding(wb v1beta1.WorkspaceBinding, taskWorkspaceName, pipelineTaskSubPath string, pr *v1beta1.PipelineRun, pipelineTaskName, pipelineWorkspaceName string, facts *resources.PipelineRunFacts) v1beta1.WorkspaceBinding {
+	var from []string
+	for _, name := range getPrecedingWorkspaceUsers(facts, pipelineTaskName, pipelineWorkspaceName) {
+		from = append(from, getOCIArtifactTag(pr.Name, name))
+	}
+	return v1beta1.WorkspaceBinding{
+		Name:    taskWorkspaceName,
+		SubPath: combinedSubPath(wb.SubPath, pipelineTaskSubPath),
+		OCI: &v1beta1.WorkspaceOCI{
+			Repository: wb.OCI.Repository,
+			From:       from,
+			To:         getOCIArtifactTag(pr.Name, pipelineTaskName),
+		},
+	}
+}
+
+// getOCIArtifactTag returns the tag of the artifact pushed by the TaskRun of a PipelineTask.
+func getOCIArtifactTag(pipelineRunName, pipelineTaskName string) string {
+	return fmt.Sprintf("%s-%s", pipelineRunName, pipelineTaskName)
+}
+
+// getPrecedingWorkspaceUsers returns the sorted names of the closest PipelineTasks that use the pipeline
+// workspace and precede the PipelineTask: its ancestors in the DAG using the workspace, or all the DAG
+// tasks using it for a finally task, without those that precede another one of them.
+func getPrecedingWorkspaceUsers(facts *resources.PipelineRunFacts, pipelineTaskName, pipelineWorkspaceName string) []string {
+	if facts == nil || facts.TasksGraph == nil {
+		return nil
+	}
+	candidates := sets.NewString()
+	if _, ok := facts.TasksGraph.Nodes[pipelineTaskName]; ok {
+		for name := range dag.GetAncestors(facts.TasksGraph, pipelineTaskName) {
+			if usesPipelineWorkspace(facts.TasksGraph.Nodes[name], pipelineWorkspaceName) {
+				candidates.Insert(name)
+			}
+		}
+	} else {
+		for name, node := range facts.TasksGraph.Nodes {
+			if usesPipelineWorkspace(node, pipelineWorkspaceName) {
+				candidates.Insert(name)
+			}
+		}
+	}
+
+	preceding := sets.NewString()
+	for name := range candidates {
+		preceding = preceding.Union(dag.GetAncestors(facts.TasksGraph, name))
+	}
+	return candidates.Difference(preceding).List()
+}
+
+func usesPipelineWorkspace(n *dag.Node, pipelineWorkspaceName string) bool {
+	pt, ok := n.Task.(v1beta1.PipelineTask)
+	if !ok {
+		return false
+	}
+	for _, ws := range pt.Workspaces {
+		if ws.Workspace == pipelineWorkspaceName {
+			return true
+		}
+	}
+	return false
+}
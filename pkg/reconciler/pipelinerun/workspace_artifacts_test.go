@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func workspaceTask(name string, runAfter ...string) v1beta1.PipelineTask {
+	return v1beta1.PipelineTask{
+		Name:       name,
+		RunAfter:   runAfter,
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "source"}},
+	}
+}
+
+// TestGetOCIWorkspaceBinding tests that each PipelineTask pulls the artifacts pushed by the closest
+// preceding PipelineTasks using the same workspace, and that finally tasks pull the last ones.
+func TestGetOCIWorkspaceBinding(t *testing.T) {
+	// fetch -> lint -> build -> unrelated
+	//      \-> test ------/
+	tasks := []v1beta1.PipelineTask{
+		workspaceTask("fetch"),
+		workspaceTask("lint", "fetch"),
+		workspaceTask("test", "fetch"),
+		workspaceTask("build", "lint", "test"),
+		{Name: "unrelated", RunAfter: []string{"build"}},
+		workspaceTask("publish", "unrelated", "fetch"),
+	}
+	g, err := dag.Build(v1beta1.PipelineTaskList(tasks), v1beta1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	facts := &resources.PipelineRunFacts{TasksGraph: g}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr"}}
+	wb := v1beta1.WorkspaceBinding{
+		Name: "source",
+		OCI:  &v1beta1.WorkspaceOCI{Repository: "registry/workspaces"},
+	}
+
+	for _, tc := range []struct {
+		pipelineTask string
+		wantFrom     []string
+	}{{
+		pipelineTask: "fetch",
+	}, {
+		pipelineTask: "lint",
+		wantFrom:     []string{"pr-fetch"},
+	}, {
+		pipelineTask: "build",
+		wantFrom:     []string{"pr-lint", "pr-test"},
+	}, {
+		pipelineTask: "publish",
+		wantFrom:     []string{"pr-build"},
+	}, {
+		pipelineTask: "finally",
+		wantFrom:     []string{"pr-publish"},
+	}} {
+		t.Run(tc.pipelineTask, func(t *testing.T) {
+			want := v1beta1.WorkspaceBinding{
+				Name:    "task-source",
+				SubPath: "sub",
+				OCI: &v1beta1.WorkspaceOCI{
+					Repository: "registry/workspaces",
+					From:       tc.wantFrom,
+					To:         "pr-" + tc.pipelineTask,
+				},
+			}
+			got := getOCIWorkspaceBinding(wb, "task-source", "sub", pr, tc.pipelineTask, "source", facts)
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("unexpected binding %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+)
+
+// validateCacheKeyResults validates that the keys of the cache workspaces only reference the results of DAG
+// tasks, and that the PipelineTasks using the workspaces run after the DAG tasks producing them, since the
+// PersistentVolumeClaim of such a cache is only known once its results are.
+func validateCacheKeyResults(facts *resources.PipelineRunFacts, workspaces []v1beta1.WorkspaceBinding) error {
+	for _, wb := range workspaces {
+		if !volumeclaim.CacheKeyReferencesResults(wb) {
+			continue
+		}
+		expressions, _ := v1beta1.GetVarSubstitutionExpressionsForCacheKey(wb.Cache.Key)
+		for _, ref := range v1beta1.NewResultRefs(expressions) {
+			if facts.TasksGraph == nil || facts.TasksGraph.Nodes[ref.PipelineTask] == nil {
+				return fmt.Errorf("the key of cache workspace %q references the results of %q, which is not a pipeline task", wb.Name, ref.PipelineTask)
+			}
+			for _, user := range getWorkspaceUsers(facts, wb.Name) {
+				if !runsAfter(facts, user, ref.PipelineTask) {
+					return fmt.Errorf("pipeline task %q uses cache workspace %q, whose key references the results of pipeline task %q, but doesn't run after it",
+						user, wb.Name, ref.PipelineTask)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateCacheKeysResolved validates that the targets that are not skipped only use the cache workspaces whose
+// key references results once the results are produced, e.g. not in a finally task when the PipelineTask
+// producing them failed. It must be called with the targets about to be run.
+func validateCacheKeysResolved(targets resources.PipelineRunState, facts *resources.PipelineRunFacts, pr *v1beta1.PipelineRun) error {
+	replacements := getCacheKeyReplacements(pr, facts)
+	for _, wb := range pr.Spec.Workspaces {
+		if !volumeclaim.CacheKeyReferencesResults(wb) || volumeclaim.IsCacheKeyResolved(wb, replacements) {
+			continue
+		}
+		for _, rprt := range targets {
+			if rprt.PipelineTask == nil || rprt.Skip(facts) {
+				continue
+			}
+			for _, ws := range rprt.PipelineTask.Workspaces {
+				if ws.Workspace == wb.Name {
+					return fmt.Errorf("pipeline task %q uses cache workspace %q but the results its key references were not produced", rprt.PipelineTask.Name, wb.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getCacheKeyReplacements returns the replacements the keys of the cache workspaces of the PipelineRun are
+// resolved with: its params, and the results of the TaskRuns of the PipelineTasks that succeeded.
+func getCacheKeyReplacements(pr *v1beta1.PipelineRun, facts *resources.PipelineRunFacts) map[string]string {
+	replacements := volumeclaim.PipelineRunCacheKeyReplacements(pr)
+	if facts == nil {
+		return replacements
+	}
+	for _, rprt := range facts.State {
+		if rprt.PipelineTask != nil && rprt.TaskRun != nil && rprt.IsSuccessful() {
+			volumeclaim.AddCacheKeyResults(replacements, rprt.PipelineTask.Name, rprt.TaskRun.Status.TaskRunResults)
+		}
+	}
+	return replacements
+}
+
+// createCachesKeyedByResults creates, or marks as used, the PersistentVolumeClaims of the cache workspaces used
+// by the PipelineTask whose key references results, which are not created when the PipelineRun starts.
+func (c *Reconciler) createCachesKeyedByResults(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask, facts *resources.PipelineRunFacts) error {
+	var caches []v1beta1.WorkspaceBinding
+	for _, wb := range pr.Spec.Workspaces {
+		if !volumeclaim.CacheKeyReferencesResults(wb) {
+			continue
+		}
+		for _, ws := range rprt.PipelineTask.Workspaces {
+			if ws.Workspace == wb.Name {
+				caches = append(caches, wb)
+				break
+			}
+		}
+	}
+	if len(caches) == 0 {
+		return nil
+	}
+	return c.pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, caches, getCacheKeyReplacements(pr, facts), pr.Namespace)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func cacheKeyedByResult(pipelineTaskName string) v1beta1.WorkspaceBinding {
+	return v1beta1.WorkspaceBinding{
+		Name: "source",
+		Cache: &v1beta1.WorkspaceCache{
+			Key:                 "go-$(tasks." + pipelineTaskName + ".results.go-sum)",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+		},
+	}
+}
+
+func cacheFacts(t *testing.T, tasks, finally []v1beta1.PipelineTask) *resources.PipelineRunFacts {
+	t.Helper()
+	g, err := dag.Build(v1beta1.PipelineTaskList(tasks), v1beta1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fg, err := dag.Build(v1beta1.PipelineTaskList(finally), map[string][]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	facts := &resources.PipelineRunFacts{TasksGraph: g, FinalTasksGraph: fg}
+	for _, pt := range append(tasks, finally...) {
+		pt := pt
+		facts.State = append(facts.State, &resources.ResolvedPipelineRunTask{PipelineTask: &pt})
+	}
+	return facts
+}
+
+// TestValidateCacheKeyResults tests that the key of a cache workspace only references the results of DAG tasks
+// that the PipelineTasks using the workspace run after.
+func TestValidateCacheKeyResults(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tasks   []v1beta1.PipelineTask
+		finally []v1beta1.PipelineTask
+		wantErr bool
+	}{{
+		name:  "users running after the producing task",
+		tasks: []v1beta1.PipelineTask{{Name: "hash"}, workspaceTask("build", "hash"), workspaceTask("test", "build")},
+	}, {
+		name:    "finally task using the cache",
+		tasks:   []v1beta1.PipelineTask{{Name: "hash"}},
+		finally: []v1beta1.PipelineTask{workspaceTask("cleanup")},
+	}, {
+		name:    "user not running after the producing task",
+		tasks:   []v1beta1.PipelineTask{{Name: "hash"}, workspaceTask("build")},
+		wantErr: true,
+	}, {
+		name:    "producing task using the cache",
+		tasks:   []v1beta1.PipelineTask{workspaceTask("hash")},
+		wantErr: true,
+	}, {
+		name:    "results of a finally task",
+		finally: []v1beta1.PipelineTask{{Name: "hash"}, workspaceTask("cleanup")},
+		wantErr: true,
+	}, {
+		name:    "results of an unknown task",
+		tasks:   []v1beta1.PipelineTask{workspaceTask("build")},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := cacheFacts(t, tc.tasks, tc.finally)
+			err := validateCacheKeyResults(facts, []v1beta1.WorkspaceBinding{cacheKeyedByResult("hash")})
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestValidateCacheKeysResolved tests that a PipelineTask can only use a cache workspace keyed by results once
+// the results are produced.
+func TestValidateCacheKeysResolved(t *testing.T) {
+	hash := v1beta1.PipelineTask{Name: "hash"}
+	facts := cacheFacts(t, []v1beta1.PipelineTask{hash}, []v1beta1.PipelineTask{workspaceTask("cleanup")})
+	pr := &v1beta1.PipelineRun{Spec: v1beta1.PipelineRunSpec{
+		Workspaces: []v1beta1.WorkspaceBinding{cacheKeyedByResult("hash")},
+	}}
+	cleanup := facts.State[1:]
+
+	facts.State[0].TaskRun = &v1beta1.TaskRun{}
+	facts.State[0].TaskRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse})
+	if err := validateCacheKeysResolved(cleanup, facts, pr); err == nil {
+		t.Errorf("expected an error using the cache when the producing task failed")
+	}
+
+	facts.State[0].TaskRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	facts.State[0].TaskRun.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "go-sum", Value: "abc"}}
+	if err := validateCacheKeysResolved(cleanup, facts, pr); err != nil {
+		t.Errorf("unexpected error using the cache once the results are produced: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+)
+
+// validateWorkspaceGitClones validates that the git repository of each workspace pre-populated from git is
+// cloned by a single PipelineTask, the first one using the workspace, and that the PipelineTasks using the
+// commit through $(workspaces.<name>.commit) run after it. Since the other PipelineTasks get the repository
+// from the volume of the workspace, it must be shared between the PipelineTasks when several of them use it.
+func validateWorkspaceGitClones(facts *resources.PipelineRunFacts, workspaces []v1beta1.WorkspaceBinding) error {
+	for _, wb := range workspaces {
+		if wb.Git == nil {
+			continue
+		}
+		if users := getWorkspaceUsers(facts, wb.Name); len(users) > 1 && !isSharedVolumeSource(wb) {
+			return fmt.Errorf("pipeline tasks %q and %q use the git repository of workspace %q, which only the first one clones, but its volume isn't shared between them: bind it to a persistentVolumeClaim, volumeClaimTemplate, cache or oci instead",
+				users[0], users[1], wb.Name)
+		}
+		cloners := getWorkspaceGitCloners(facts, wb.Name)
+		if len(cloners) > 1 {
+			return fmt.Errorf("pipeline tasks %q and %q can both clone the git repository of workspace %q: add a runAfter ordering so that a single pipeline task uses the workspace first",
+				cloners[0], cloners[1], wb.Name)
+		}
+
+		commit := fmt.Sprintf("$(workspaces.%s.commit)", wb.Name)
+		for _, rprt := range facts.State {
+			if rprt.PipelineTask == nil || !resources.PipelineTaskReferences(rprt.PipelineTask, commit) {
+				continue
+			}
+			if len(cloners) == 0 {
+				return fmt.Errorf("pipeline task %q uses %s but no pipeline task clones the git repository of workspace %q", rprt.PipelineTask.Name, commit, wb.Name)
+			}
+			if !runsAfter(facts, rprt.PipelineTask.Name, cloners[0]) {
+				return fmt.Errorf("pipeline task %q uses %s but doesn't run after pipeline task %q that clones it", rprt.PipelineTask.Name, commit, cloners[0])
+			}
+		}
+	}
+	return nil
+}
+
+// getWorkspaceGitCloners returns the sorted names of the PipelineTasks that clone the git repository of the
+// pipeline workspace: the DAG tasks using it that are not preceded by another one, or the finally tasks using it
+// when no DAG task uses it.
+func getWorkspaceGitCloners(facts *resources.PipelineRunFacts, pipelineWorkspaceName string) []string {
+	var cloners []string
+	for _, g := range []*dag.Graph{facts.TasksGraph, facts.FinalTasksGraph} {
+		if g == nil {
+			continue
+		}
+		for name, node := range g.Nodes {
+			if usesPipelineWorkspace(node, pipelineWorkspaceName) && len(getPrecedingWorkspaceUsers(facts, name, pipelineWorkspaceName)) == 0 {
+				cloners = append(cloners, name)
+			}
+		}
+		if len(cloners) > 0 {
+			break
+		}
+	}
+	sort.Strings(cloners)
+	return cloners
+}
+
+// getWorkspaceUsers returns the sorted names of the DAG and finally tasks using the pipeline workspace.
+func getWorkspaceUsers(facts *resources.PipelineRunFacts, pipelineWorkspaceName string) []string {
+	var users []string
+	for _, g := range []*dag.Graph{facts.TasksGraph, facts.FinalTasksGraph} {
+		if g == nil {
+			continue
+		}
+		for name, node := range g.Nodes {
+			if usesPipelineWorkspace(node, pipelineWorkspaceName) {
+				users = append(users, name)
+			}
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// isSharedVolumeSource returns true if the content of the workspace is shared between the PipelineTasks using
+// it: its volume is not created for each TaskRun, like emptyDir and csi volumes are.
+func isSharedVolumeSource(wb v1beta1.WorkspaceBinding) bool {
+	return wb.EmptyDir == nil && wb.CSI == nil
+}
+
+// runsAfter returns true if the PipelineTask runs after the DAG task: it is a finally task, or the DAG task is one
+// of its ancestors.
+func runsAfter(facts *resources.PipelineRunFacts, pipelineTaskName, dagTaskName string) bool {
+	if facts.TasksGraph == nil {
+		return false
+	}
+	if _, ok := facts.TasksGraph.Nodes[dagTaskName]; !ok {
+		return false
+	}
+	if _, ok := facts.TasksGraph.Nodes[pipelineTaskName]; !ok {
+		return true
+	}
+	return dag.GetAncestors(facts.TasksGraph, pipelineTaskName).Has(dagTaskName)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func usingCommit(pt v1beta1.PipelineTask) v1beta1.PipelineTask {
+	pt.Params = []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewArrayOrString("$(workspaces.source.commit)")}}
+	return pt
+}
+
+// TestValidateWorkspaceGitClones tests that a git workspace is cloned by a single PipelineTask, and that
+// the PipelineTasks using its commit run after it.
+func TestValidateWorkspaceGitClones(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tasks   []v1beta1.PipelineTask
+		finally []v1beta1.PipelineTask
+		// emptyDir binds the workspace to an emptyDir, which isn't shared between the PipelineTasks.
+		emptyDir bool
+		wantErr  bool
+	}{{
+		name:  "single root",
+		tasks: []v1beta1.PipelineTask{workspaceTask("fetch"), usingCommit(workspaceTask("build", "fetch"))},
+	}, {
+		name:    "finally task using the commit",
+		tasks:   []v1beta1.PipelineTask{workspaceTask("fetch")},
+		finally: []v1beta1.PipelineTask{usingCommit(v1beta1.PipelineTask{Name: "notify"})},
+	}, {
+		name:    "several roots",
+		tasks:   []v1beta1.PipelineTask{workspaceTask("lint"), workspaceTask("test")},
+		wantErr: true,
+	}, {
+		name:    "several finally tasks",
+		finally: []v1beta1.PipelineTask{workspaceTask("lint"), workspaceTask("test")},
+		wantErr: true,
+	}, {
+		name:    "commit used by the cloning task",
+		tasks:   []v1beta1.PipelineTask{usingCommit(workspaceTask("fetch"))},
+		wantErr: true,
+	}, {
+		name:    "commit used by a task not running after the cloning task",
+		tasks:   []v1beta1.PipelineTask{workspaceTask("fetch"), usingCommit(v1beta1.PipelineTask{Name: "build"})},
+		wantErr: true,
+	}, {
+		name:    "commit used without a cloning task",
+		tasks:   []v1beta1.PipelineTask{usingCommit(v1beta1.PipelineTask{Name: "build"})},
+		wantErr: true,
+	}, {
+		name:     "emptyDir used by a single task",
+		tasks:    []v1beta1.PipelineTask{workspaceTask("fetch"), usingCommit(v1beta1.PipelineTask{Name: "build", RunAfter: []string{"fetch"}})},
+		emptyDir: true,
+	}, {
+		name:     "emptyDir used by several tasks",
+		tasks:    []v1beta1.PipelineTask{workspaceTask("fetch"), workspaceTask("build", "fetch")},
+		emptyDir: true,
+		wantErr:  true,
+	}, {
+		name:     "emptyDir used by a task and a finally task",
+		tasks:    []v1beta1.PipelineTask{workspaceTask("fetch")},
+		finally:  []v1beta1.PipelineTask{workspaceTask("cleanup")},
+		emptyDir: true,
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := dag.Build(v1beta1.PipelineTaskList(tc.tasks), v1beta1.PipelineTaskList(tc.tasks).Deps())
+			if err != nil {
+				t.Fatal(err)
+			}
+			fg, err := dag.Build(v1beta1.PipelineTaskList(tc.finally), map[string][]string{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			facts := &resources.PipelineRunFacts{TasksGraph: g, FinalTasksGraph: fg}
+			for _, pt := range append(tc.tasks, tc.finally...) {
+				pt := pt
+				facts.State = append(facts.State, &resources.ResolvedPipelineRunTask{PipelineTask: &pt})
+			}
+			workspaces := []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				Git:  &v1beta1.WorkspaceGit{URL: "https://github.com/tektoncd/pipeline"},
+			}}
+			if tc.emptyDir {
+				workspaces[0].EmptyDir = &corev1.EmptyDirVolumeSource{}
+			}
+
+			err = validateWorkspaceGitClones(facts, workspaces)
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
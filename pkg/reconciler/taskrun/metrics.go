@@ -62,6 +62,14 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	stepCPUTime = stats.Float64("taskrun_step_cpu_time_seconds",
+		"The CPU time used by the taskrun's step in seconds",
+		stats.UnitDimensionless)
+
+	stepMaxRSS = stats.Int64("taskrun_step_max_rss_bytes",
+		"The peak resident set size of the taskrun's step in bytes",
+		stats.UnitBytes)
 )
 
 type Recorder struct {
@@ -74,6 +82,7 @@ type Recorder struct {
 	pipeline    tag.Key
 	pipelineRun tag.Key
 	pod         tag.Key
+	step        tag.Key
 
 	ReportingPeriod time.Duration
 }
@@ -130,6 +139,12 @@ func NewRecorder() (*Recorder, error) {
 	}
 	r.pod = pod
 
+	step, err := tag.NewKey("step")
+	if err != nil {
+		return nil, err
+	}
+	r.step = step
+
 	err = view.Register(
 		&view.View{
 			Description: trDuration.Description(),
@@ -166,6 +181,18 @@ func NewRecorder() (*Recorder, error) {
 			Aggregation: view.Sum(),
 			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.status, r.pipeline, r.pipelineRun},
 		},
+		&view.View{
+			Description: stepCPUTime.Description(),
+			Measure:     stepCPUTime,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
+		&view.View{
+			Description: stepMaxRSS.Description(),
+			Measure:     stepMaxRSS,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
 	)
 
 	if err != nil {
@@ -319,6 +346,51 @@ func (r *Recorder) RecordPodLatency(pod *corev1.Pod, tr *v1beta1.TaskRun) error
 	return nil
 }
 
+// StepResourceUsage logs the CPU time and peak memory used by each of the
+// TaskRun's steps that reported its resource usage
+// returns an error if its failed to log the metrics
+func (r *Recorder) StepResourceUsage(tr *v1beta1.TaskRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	for _, step := range tr.Status.Steps {
+		usage := step.ResourceUsage
+		if usage == nil {
+			continue
+		}
+		ctx, err := tag.New(
+			context.Background(),
+			tag.Insert(r.task, taskName),
+			tag.Insert(r.taskRun, tr.Name),
+			tag.Insert(r.namespace, tr.Namespace),
+			tag.Insert(r.step, step.Name),
+		)
+		if err != nil {
+			return err
+		}
+
+		var cpuTime time.Duration
+		if usage.UserCPUTime != nil {
+			cpuTime += usage.UserCPUTime.Duration
+		}
+		if usage.SystemCPUTime != nil {
+			cpuTime += usage.SystemCPUTime.Duration
+		}
+		metrics.Record(ctx, stepCPUTime.M(cpuTime.Seconds()))
+		if usage.MaxRSS != nil {
+			metrics.Record(ctx, stepMaxRSS.M(usage.MaxRSS.Value()))
+		}
+	}
+
+	return nil
+}
+
 // CloudEvents logs the number of cloud events sent for TaskRun
 // returns an error if it fails to log the metrics
 func (r *Recorder) CloudEvents(tr *v1beta1.TaskRun) error {
@@ -26,6 +26,7 @@ import (
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -451,6 +452,48 @@ func TestRecordCloudEvents(t *testing.T) {
 	}
 }
 
+func TestRecordStepResourceUsage(t *testing.T) {
+	unregisterMetrics()
+
+	maxRSS := resource.MustParse("64Mi")
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					Name: "build",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						UserCPUTime:   &metav1.Duration{Duration: 3 * time.Second},
+						SystemCPUTime: &metav1.Duration{Duration: 500 * time.Millisecond},
+						MaxRSS:        &maxRSS,
+					},
+				}, {
+					Name: "no-usage",
+				}},
+			},
+		},
+	}
+
+	metrics, err := NewRecorder()
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	if err := metrics.StepResourceUsage(taskRun); err != nil {
+		t.Errorf("StepResourceUsage: %v", err)
+	}
+	expectedTags := map[string]string{
+		"task":      "task-1",
+		"taskrun":   "test-taskrun",
+		"namespace": "foo",
+		"step":      "build",
+	}
+	metricstest.CheckLastValueData(t, "taskrun_step_cpu_time_seconds", expectedTags, 3.5)
+	metricstest.CheckLastValueData(t, "taskrun_step_max_rss_bytes", expectedTags, 64*1024*1024)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_step_cpu_time_seconds", "taskrun_step_max_rss_bytes")
 }
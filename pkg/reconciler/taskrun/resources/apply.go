@@ -30,6 +30,12 @@ import (
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
 func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1beta1.ParamSpec) *v1beta1.TaskSpec {
+	stringReplacements, arrayReplacements := paramReplacements(tr, defaults...)
+	return ApplyReplacements(spec, stringReplacements, arrayReplacements)
+}
+
+// paramReplacements returns the values of the params from a TaskRun.Input.Parameters, falling back to the defaults.
+func paramReplacements(tr *v1beta1.TaskRun, defaults ...v1beta1.ParamSpec) (map[string]string, map[string][]string) {
 	// This assumes that the TaskRun inputs have been validated against what the Task requests.
 
 	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
@@ -63,7 +69,7 @@ func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1
 			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.ArrayVal
 		}
 	}
-	return ApplyReplacements(spec, stringReplacements, arrayReplacements)
+	return stringReplacements, arrayReplacements
 }
 
 // ApplyResources applies the substitution from values in resources which are referenced in spec as subitems
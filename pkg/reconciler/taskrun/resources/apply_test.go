@@ -40,6 +40,7 @@ var (
 		KubeconfigWriterImage:    "override-with-kubeconfig-writer-image:latest",
 		ShellImage:               "busybox",
 		GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
+		AwsCLIImage:              "docker.io/amazon/aws-cli",
 		BuildGCSFetcherImage:     "gcr.io/cloud-tbs/gcs-fetcher:latest",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyOverrides returns a copy of the spec in which the overrides are merged
+// into the Steps and Sidecars they name. The requests and limits of the
+// overrides replace the ones for the same resources, their environment
+// variables replace the ones with the same names and their envFrom sources
+// are appended.
+func ApplyOverrides(spec *v1beta1.TaskSpec, stepOverrides []v1beta1.TaskRunStepOverride, sidecarOverrides []v1beta1.TaskRunSidecarOverride) *v1beta1.TaskSpec {
+	spec = spec.DeepCopy()
+	for _, o := range stepOverrides {
+		for i := range spec.Steps {
+			if spec.Steps[i].Name == o.Name {
+				mergeOverride(&spec.Steps[i].Container, o.Resources, o.Env, o.EnvFrom)
+			}
+		}
+	}
+	for _, o := range sidecarOverrides {
+		for i := range spec.Sidecars {
+			if spec.Sidecars[i].Name == o.Name {
+				mergeOverride(&spec.Sidecars[i].Container, o.Resources, o.Env, o.EnvFrom)
+			}
+		}
+	}
+	return spec
+}
+
+func mergeOverride(c *corev1.Container, resources corev1.ResourceRequirements, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) {
+	c.Resources.Requests = mergeResourceList(c.Resources.Requests, resources.Requests)
+	c.Resources.Limits = mergeResourceList(c.Resources.Limits, resources.Limits)
+	for _, e := range env {
+		found := false
+		for i := range c.Env {
+			if c.Env[i].Name == e.Name {
+				c.Env[i] = e
+				found = true
+			}
+		}
+		if !found {
+			c.Env = append(c.Env, e)
+		}
+	}
+	c.EnvFrom = append(c.EnvFrom, envFrom...)
+}
+
+func mergeResourceList(list, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(overrides) == 0 {
+		return list
+	}
+	if list == nil {
+		list = corev1.ResourceList{}
+	}
+	for name, q := range overrides {
+		list[name] = q
+	}
+	return list
+}
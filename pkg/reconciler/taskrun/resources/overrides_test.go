@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}},
+			},
+		}, {
+			Container: corev1.Container{Name: "test"},
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Container: corev1.Container{Name: "proxy"},
+		}},
+	}
+	stepOverrides := []v1beta1.TaskRunStepOverride{{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+		Env: []corev1.EnvVar{{Name: "FOO", Value: "overridden"}, {Name: "BAZ", Value: "baz"}},
+		EnvFrom: []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}},
+		}},
+	}}
+	sidecarOverrides := []v1beta1.TaskRunSidecarOverride{{
+		Name: "proxy",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		},
+	}}
+
+	want := spec.DeepCopy()
+	want.Steps[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	want.Steps[0].Env = []corev1.EnvVar{{Name: "FOO", Value: "overridden"}, {Name: "BAR", Value: "bar"}, {Name: "BAZ", Value: "baz"}}
+	want.Steps[0].EnvFrom = stepOverrides[0].EnvFrom
+	want.Sidecars[0].Resources = sidecarOverrides[0].Resources
+
+	got := ApplyOverrides(spec, stepOverrides, sidecarOverrides)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyOverrides() %s", diff.PrintWantGot(d))
+	}
+	if spec.Steps[0].Env[0].Value != "foo" {
+		t.Error("ApplyOverrides() modified the original spec")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ScriptParamEnv holds the environment variables with the values of the
+// params referenced in the scripts of each step and sidecar of a TaskSpec.
+type ScriptParamEnv struct {
+	Steps    [][]corev1.EnvVar
+	Sidecars [][]corev1.EnvVar
+}
+
+// ApplyParametersAsScriptEnv replaces the references to string params in the
+// shell scripts of the steps and sidecars with references to environment
+// variables holding the params' values, which are returned to be added to the
+// steps and sidecars with AddScriptParamEnv. This way the params' values are
+// never interpreted as part of the scripts. Scripts run by other interpreters
+// are left as they are.
+func ApplyParametersAsScriptEnv(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1beta1.ParamSpec) (*v1beta1.TaskSpec, ScriptParamEnv) {
+	spec = spec.DeepCopy()
+	values, _ := paramReplacements(tr, defaults...)
+	env := ScriptParamEnv{
+		Steps:    make([][]corev1.EnvVar, len(spec.Steps)),
+		Sidecars: make([][]corev1.EnvVar, len(spec.Sidecars)),
+	}
+	for i := range spec.Steps {
+		s := &spec.Steps[i]
+		s.Script, env.Steps[i] = scriptParamsToEnv(s.Script, values)
+	}
+	for i := range spec.Sidecars {
+		s := &spec.Sidecars[i]
+		s.Script, env.Sidecars[i] = scriptParamsToEnv(s.Script, values)
+	}
+	return spec, env
+}
+
+// AddScriptParamEnv adds the environment variables returned by
+// ApplyParametersAsScriptEnv to the steps and sidecars. It must be called
+// after all the variables have been substituted, and before any step is added
+// to the spec, so that the params' values are passed verbatim.
+func AddScriptParamEnv(spec *v1beta1.TaskSpec, env ScriptParamEnv) *v1beta1.TaskSpec {
+	spec = spec.DeepCopy()
+	for i, e := range env.Steps {
+		if len(e) > 0 {
+			spec.Steps[i].Env = append(spec.Steps[i].Env, e...)
+		}
+	}
+	for i, e := range env.Sidecars {
+		if len(e) > 0 {
+			spec.Sidecars[i].Env = append(spec.Sidecars[i].Env, e...)
+		}
+	}
+	return spec
+}
+
+func scriptParamsToEnv(script string, values map[string]string) (string, []corev1.EnvVar) {
+	if script == "" || !v1beta1.IsShellScript(script) {
+		return script, nil
+	}
+	vars := map[string]string{}
+	script = v1beta1.ReplaceScriptParams(script, func(ref, param string, quoting v1beta1.ScriptQuoting) string {
+		value, ok := values["params."+param]
+		if !ok || quoting == v1beta1.Literal {
+			// Array params can't be referenced in scripts, and the
+			// shell doesn't expand variables in here-documents with
+			// a quoted delimiter, so leave these to the usual
+			// substitution.
+			return ref
+		}
+		name := v1beta1.ScriptParamEnvName(param)
+		// Kubernetes expands $(VAR) in the values of environment
+		// variables, unless escaped as $$(VAR).
+		vars[name] = strings.Replace(value, "$", "$$", -1)
+		return quoteScriptParamEnv(name, quoting)
+	})
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var env []corev1.EnvVar
+	for _, name := range names {
+		env = append(env, corev1.EnvVar{Name: name, Value: vars[name]})
+	}
+	return script, env
+}
+
+// quoteScriptParamEnv returns the reference to the environment variable name
+// that expands to its value verbatim, as a single word, where the script quotes
+// a reference to a param as quoting.
+func quoteScriptParamEnv(name string, quoting v1beta1.ScriptQuoting) string {
+	ref := "${" + name + "}"
+	switch quoting {
+	case v1beta1.DoubleQuoted:
+		return ref
+	case v1beta1.SingleQuoted:
+		// Close the single quotes around the double-quoted reference.
+		return `'"` + ref + `"'`
+	case v1beta1.ANSICQuoted:
+		return `'"` + ref + `"$'`
+	default:
+		return `"` + ref + `"`
+	}
+}
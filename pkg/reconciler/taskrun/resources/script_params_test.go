@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyParametersAsScriptEnv(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "pr-title",
+			Type:    v1beta1.ParamTypeString,
+			Default: v1beta1.NewArrayOrString("default title"),
+		}, {
+			Name: "args",
+			Type: v1beta1.ParamTypeArray,
+		}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "no-shebang"},
+			Script:    `echo "$(params.pr-title)" "$(inputs.params.pr-title)"`,
+		}, {
+			Container: corev1.Container{
+				Name: "existing-env",
+				Env:  []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			},
+			Script: "#!/usr/bin/env bash\necho $(params.pr-title)",
+		}, {
+			Container: corev1.Container{Name: "python"},
+			Script:    "#!/usr/bin/env python3\nprint('$(params.pr-title)')",
+		}, {
+			Container: corev1.Container{Name: "unknown-param", Args: []string{"$(params.pr-title)"}},
+			Script:    "#!/bin/sh\necho $(params.args) $(context.taskRun.name)",
+		}, {
+			Container: corev1.Container{Name: "no-script", Args: []string{"$(params.pr-title)"}},
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Container: corev1.Container{Name: "sidecar"},
+			Script:    "#!/bin/bash\necho $(params.pr-title)",
+		}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "pr-title",
+				Value: *v1beta1.NewArrayOrString(`"; rm -rf / $(HOME)`),
+			}, {
+				Name:  "args",
+				Value: *v1beta1.NewArrayOrString("a", "b"),
+			}},
+		},
+	}
+	escaped := `"; rm -rf / $$(HOME)`
+
+	want := spec.DeepCopy()
+	want.Steps[0].Script = `echo "${PARAMS_PR_TITLE}" "${PARAMS_PR_TITLE}"`
+	want.Steps[1].Script = "#!/usr/bin/env bash\necho \"${PARAMS_PR_TITLE}\""
+	want.Sidecars[0].Script = "#!/bin/bash\necho \"${PARAMS_PR_TITLE}\""
+	wantEnv := ScriptParamEnv{
+		Steps: [][]corev1.EnvVar{
+			{{Name: "PARAMS_PR_TITLE", Value: escaped}},
+			{{Name: "PARAMS_PR_TITLE", Value: escaped}},
+			nil,
+			nil,
+			nil,
+		},
+		Sidecars: [][]corev1.EnvVar{
+			{{Name: "PARAMS_PR_TITLE", Value: escaped}},
+		},
+	}
+
+	got, gotEnv := ApplyParametersAsScriptEnv(spec, tr, spec.Params...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParametersAsScriptEnv() %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantEnv, gotEnv); d != "" {
+		t.Errorf("ApplyParametersAsScriptEnv() env %s", diff.PrintWantGot(d))
+	}
+	if spec.Steps[0].Script != `echo "$(params.pr-title)" "$(inputs.params.pr-title)"` {
+		t.Error("ApplyParametersAsScriptEnv() modified the original spec")
+	}
+
+	want.Steps[0].Env = []corev1.EnvVar{{Name: "PARAMS_PR_TITLE", Value: escaped}}
+	want.Steps[1].Env = []corev1.EnvVar{
+		{Name: "FOO", Value: "bar"},
+		{Name: "PARAMS_PR_TITLE", Value: escaped},
+	}
+	want.Sidecars[0].Env = []corev1.EnvVar{{Name: "PARAMS_PR_TITLE", Value: escaped}}
+	if d := cmp.Diff(want, AddScriptParamEnv(got, gotEnv)); d != "" {
+		t.Errorf("AddScriptParamEnv() %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestApplyParametersAsScriptEnvQuoting tests that the references to params are replaced with references to
+// environment variables that the shell expands to the params' values verbatim, however the script quotes them.
+func TestApplyParametersAsScriptEnvQuoting(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		script string
+		want   string
+	}{{
+		name:   "unquoted",
+		script: "echo $(params.title)",
+		want:   `echo "${PARAMS_TITLE}"`,
+	}, {
+		name:   "double quoted",
+		script: `echo "title: $(params.title)"`,
+		want:   `echo "title: ${PARAMS_TITLE}"`,
+	}, {
+		name:   "single quoted",
+		script: "echo 'title: $(params.title)'",
+		want:   `echo 'title: '"${PARAMS_TITLE}"''`,
+	}, {
+		name:   "ANSI-C quoted",
+		script: `echo $'title:\t$(params.title)'`,
+		want:   `echo $'title:\t'"${PARAMS_TITLE}"$''`,
+	}, {
+		name:   "quotes in comments",
+		script: "# don't quote\necho $(params.title)",
+		want:   "# don't quote\necho \"${PARAMS_TITLE}\"",
+	}, {
+		name:   "here-document",
+		script: "cat <<EOF\ntitle: '$(params.title)'\nEOF\necho $(params.title)",
+		want:   "cat <<EOF\ntitle: '${PARAMS_TITLE}'\nEOF\necho \"${PARAMS_TITLE}\"",
+	}, {
+		name:   "here-document with a quoted delimiter",
+		script: "cat <<-'EOF'\n\ttitle: $(params.title)\n\tEOF\necho $(params.title)",
+		want:   "cat <<-'EOF'\n\ttitle: $(params.title)\n\tEOF\necho \"${PARAMS_TITLE}\"",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{Name: "title"}},
+				Steps:  []v1beta1.Step{{Script: tc.script}},
+			}
+			tr := &v1beta1.TaskRun{
+				Spec: v1beta1.TaskRunSpec{
+					Params: []v1beta1.Param{{Name: "title", Value: *v1beta1.NewArrayOrString("a  *")}},
+				},
+			}
+			got, _ := ApplyParametersAsScriptEnv(spec, tr, spec.Params...)
+			if d := cmp.Diff(tc.want, got.Steps[0].Script); d != "" {
+				t.Errorf("ApplyParametersAsScriptEnv() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestAddScriptParamEnvAfterSubstitution tests that the values of the params referenced in scripts are passed
+// verbatim, even when they contain variables that are substituted in the rest of the spec.
+func TestAddScriptParamEnvAfterSubstitution(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{Name: "title"}, {Name: "secret"}},
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "print"},
+			Script:    `echo "$(params.title)"`,
+		}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "title",
+				Value: *v1beta1.NewArrayOrString("$(params.secret) $(context.taskRun.name)"),
+			}, {
+				Name:  "secret",
+				Value: *v1beta1.NewArrayOrString("s3cr3t"),
+			}},
+		},
+	}
+	got, env := ApplyParametersAsScriptEnv(spec, tr, spec.Params...)
+	got = ApplyParameters(got, tr, spec.Params...)
+	got = ApplyContexts(got, &ResolvedTaskResources{TaskName: "task"}, &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "run"}})
+	got = AddScriptParamEnv(got, env)
+
+	want := []corev1.EnvVar{{Name: "PARAMS_TITLE", Value: "$$(params.secret) $$(context.taskRun.name)"}}
+	if d := cmp.Diff(want, got.Steps[0].Env); d != "" {
+		t.Errorf("env %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetConfigMap is a function used to retrieve ConfigMaps by name.
+type GetConfigMap func(ctx context.Context, name string) (*corev1.ConfigMap, error)
+
+// GetBundleFile is a function used to retrieve the contents of the files in
+// the Tekton Bundle containing a Task.
+type GetBundleFile func(ctx context.Context, name string) ([]byte, error)
+
+// errNotInBundle is returned when a Step loads its script from a bundle file
+// but its Task doesn't come from a Tekton Bundle.
+var errNotInBundle = errors.New("the Task is not fetched from a Tekton Bundle")
+
+// GetBundleFileFunc returns a GetBundleFile function that fetches the files
+// from the bundle the TaskRef refers to, authorizing with the service account
+// like GetTaskFunc does.
+func GetBundleFileFunc(ctx context.Context, k8s kubernetes.Interface, tr *v1beta1.TaskRef, namespace, saName string) GetBundleFile {
+	cfg := config.FromContextOrDefaults(ctx)
+	if !cfg.FeatureFlags.EnableTektonOCIBundles || tr == nil || tr.Bundle == "" {
+		return func(context.Context, string) ([]byte, error) {
+			return nil, errNotInBundle
+		}
+	}
+	return func(ctx context.Context, name string) ([]byte, error) {
+		kc, err := k8schain.New(ctx, k8s, k8schain.Options{
+			Namespace:          namespace,
+			ServiceAccountName: saName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get keychain: %w", err)
+		}
+		resolver := oci.NewResolver(tr.Bundle, kc).(*oci.Resolver)
+		return resolver.GetFile(name)
+	}
+}
+
+// ResolveScriptRefs returns a copy of the spec in which each Step with a
+// ScriptRef runs the script it refers to, as if it were specified in its
+// Script, along with the digests of the scripts loaded.
+func ResolveScriptRefs(ctx context.Context, spec *v1beta1.TaskSpec, getConfigMap GetConfigMap, getBundleFile GetBundleFile) (*v1beta1.TaskSpec, []v1beta1.ResolvedScript, error) {
+	var resolved []v1beta1.ResolvedScript
+	spec = spec.DeepCopy()
+	for i := range spec.Steps {
+		s := &spec.Steps[i]
+		if s.ScriptRef == nil {
+			continue
+		}
+		script, err := getScript(ctx, s.ScriptRef, getConfigMap, getBundleFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load the script of step %d %q: %w", i, s.Name, err)
+		}
+		resolved = append(resolved, v1beta1.ResolvedScript{
+			Name:      s.Name,
+			ScriptRef: *s.ScriptRef,
+			Digest:    fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(script))),
+		})
+		s.Script = script
+		s.ScriptRef = nil
+	}
+	return spec, resolved, nil
+}
+
+func getScript(ctx context.Context, ref *v1beta1.ScriptRef, getConfigMap GetConfigMap, getBundleFile GetBundleFile) (string, error) {
+	switch {
+	case ref.ConfigMapKeyRef != nil:
+		cm, err := getConfigMap(ctx, ref.ConfigMapKeyRef.Name)
+		if err != nil {
+			return "", err
+		}
+		script, ok := cm.Data[ref.ConfigMapKeyRef.Key]
+		if !ok {
+			return "", fmt.Errorf("ConfigMap %q has no key %q", ref.ConfigMapKeyRef.Name, ref.ConfigMapKeyRef.Key)
+		}
+		return script, nil
+	case ref.BundleFile != "":
+		b, err := getBundleFile(ctx, ref.BundleFile)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", errors.New("the scriptRef doesn't refer to a script")
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/registry"
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+var scriptsConfigMap = &corev1.ConfigMap{
+	ObjectMeta: metav1.ObjectMeta{Name: "scripts"},
+	Data:       map[string]string{"build.sh": "make $(params.target)"},
+}
+
+func getScriptsConfigMap(_ context.Context, name string) (*corev1.ConfigMap, error) {
+	if name != scriptsConfigMap.Name {
+		return nil, fmt.Errorf("configmap %q not found", name)
+	}
+	return scriptsConfigMap, nil
+}
+
+func getBundleFile(_ context.Context, name string) ([]byte, error) {
+	if name != "test.sh" {
+		return nil, fmt.Errorf("file %q not found", name)
+	}
+	return []byte("make test"), nil
+}
+
+func configMapScriptRef(name, key string) *v1beta1.ScriptRef {
+	return &v1beta1.ScriptRef{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
+
+func TestResolveScriptRefs(t *testing.T) {
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "build", Image: "ubuntu"},
+			ScriptRef: configMapScriptRef("scripts", "build.sh"),
+		}, {
+			Container: corev1.Container{Name: "test", Image: "ubuntu"},
+			ScriptRef: &v1beta1.ScriptRef{BundleFile: "test.sh"},
+		}, {
+			Container: corev1.Container{Name: "inline", Image: "ubuntu"},
+			Script:    "echo done",
+		}},
+	}
+
+	want := spec.DeepCopy()
+	want.Steps[0].Script = "make $(params.target)"
+	want.Steps[0].ScriptRef = nil
+	want.Steps[1].Script = "make test"
+	want.Steps[1].ScriptRef = nil
+	wantResolved := []v1beta1.ResolvedScript{{
+		Name:      "build",
+		ScriptRef: *configMapScriptRef("scripts", "build.sh"),
+		// echo -n 'make $(params.target)' | sha256sum
+		Digest: "sha256:80398e6982f9aa1ac5bfd387803e313e837d64433476a6274f830d27523692ad",
+	}, {
+		Name:      "test",
+		ScriptRef: v1beta1.ScriptRef{BundleFile: "test.sh"},
+		// echo -n 'make test' | sha256sum
+		Digest: "sha256:22cc66aa7d2624b4eb4d5b61658614cd6c32477b123afbf7dbc7fc3d7d0f3713",
+	}}
+
+	got, gotResolved, err := ResolveScriptRefs(context.Background(), spec, getScriptsConfigMap, getBundleFile)
+	if err != nil {
+		t.Fatalf("ResolveScriptRefs() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ResolveScriptRefs() spec %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantResolved, gotResolved); d != "" {
+		t.Errorf("ResolveScriptRefs() resolved scripts %s", diff.PrintWantGot(d))
+	}
+	if spec.Steps[0].ScriptRef == nil {
+		t.Error("ResolveScriptRefs() modified the original spec")
+	}
+}
+
+func TestResolveScriptRefsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		ref     *v1beta1.ScriptRef
+		wantErr string
+	}{{
+		name:    "missing configmap",
+		ref:     configMapScriptRef("missing", "build.sh"),
+		wantErr: `failed to load the script of step 0 "build": configmap "missing" not found`,
+	}, {
+		name:    "missing key",
+		ref:     configMapScriptRef("scripts", "missing.sh"),
+		wantErr: `failed to load the script of step 0 "build": ConfigMap "scripts" has no key "missing.sh"`,
+	}, {
+		name:    "missing bundle file",
+		ref:     &v1beta1.ScriptRef{BundleFile: "missing.sh"},
+		wantErr: `failed to load the script of step 0 "build": file "missing.sh" not found`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "build", Image: "ubuntu"},
+					ScriptRef: tc.ref,
+				}},
+			}
+			_, _, err := ResolveScriptRefs(context.Background(), spec, getScriptsConfigMap, getBundleFile)
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("ResolveScriptRefs() = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetBundleFileFunc(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := test.CreateImageWithFiles(u.Host+"/bundle-files", map[string]string{"test.sh": "make test"}, tb.Task("simple-task", tb.TaskType))
+	if err != nil {
+		t.Fatalf("failed to upload test image: %s", err.Error())
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableTektonOCIBundles: true},
+	})
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+	})
+
+	fn := GetBundleFileFunc(ctx, kubeclient, &v1beta1.TaskRef{Name: "simple-task", Bundle: ref}, "default", "default")
+	got, err := fn(ctx, "test.sh")
+	if err != nil {
+		t.Fatalf("failed to get the bundle file: %v", err)
+	}
+	if string(got) != "make test" {
+		t.Errorf("got bundle file %q, want %q", got, "make test")
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		ref  *v1beta1.TaskRef
+	}{
+		{name: "bundles disabled", ctx: context.Background(), ref: &v1beta1.TaskRef{Name: "simple-task", Bundle: ref}},
+		{name: "no bundle", ctx: ctx, ref: &v1beta1.TaskRef{Name: "simple-task"}},
+		{name: "embedded task", ctx: ctx},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fn := GetBundleFileFunc(tc.ctx, kubeclient, tc.ref, "default", "default")
+			if _, err := fn(tc.ctx, "test.sh"); !errors.Is(err, errNotInBundle) {
+				t.Errorf("expected errNotInBundle, got %v", err)
+			}
+		})
+	}
+}
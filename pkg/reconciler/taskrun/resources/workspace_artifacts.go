@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/storage"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// workspaceArtifactsDir is the directory under which the artifact steps mount the volumes of OCI workspaces.
+const workspaceArtifactsDir = "/tekton/workspace-artifacts"
+
+// AddWorkspaceArtifactSteps prepends to the steps of ts a step pulling each artifact an OCI workspace
+// is populated from, and appends a step pushing the content of each OCI workspace with a destination
+// tag. The artifact steps mount the whole volume of the workspace, writable and regardless of its
+// subPath, under a directory of their own.
+func AddWorkspaceArtifactSteps(workspaceArtifactImage string, ts *v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, volumes map[string]corev1.Volume) *v1beta1.TaskSpec {
+	var pullSteps, pushSteps []v1beta1.Step
+	for _, w := range wb {
+		if w.OCI == nil {
+			continue
+		}
+		mountPath := filepath.Join(workspaceArtifactsDir, w.Name)
+		vm := corev1.VolumeMount{
+			Name:      volumes[w.Name].Name,
+			MountPath: mountPath,
+		}
+		as := &storage.ArtifactOCI{
+			Repository:             w.OCI.Repository,
+			WorkspaceArtifactImage: workspaceArtifactImage,
+		}
+		for _, tag := range w.OCI.From {
+			pullSteps = append(pullSteps, withVolumeMount(as.GetCopyFromStorageToSteps(w.Name, tag, mountPath), vm)...)
+		}
+		if w.OCI.To != "" {
+			pushSteps = append(pushSteps, withVolumeMount(as.GetCopyToStorageFromSteps(w.Name, mountPath, w.OCI.To), vm)...)
+		}
+	}
+	if len(pullSteps) == 0 && len(pushSteps) == 0 {
+		return ts
+	}
+
+	ts = ts.DeepCopy()
+	steps := append(pullSteps, ts.Steps...)
+	ts.Steps = append(steps, pushSteps...)
+	return ts
+}
+
+func withVolumeMount(steps []v1beta1.Step, vm corev1.VolumeMount) []v1beta1.Step {
+	for i := range steps {
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, vm)
+	}
+	return steps
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddWorkspaceArtifactSteps(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name:     "source",
+			ReadOnly: true,
+		}, {
+			Name: "other",
+		}},
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name:  "build",
+			Image: "golang",
+		}}},
+	}
+	volumes := map[string]corev1.Volume{
+		"source": {Name: "ws-abcde"},
+		"other":  {Name: "ws-fghij"},
+	}
+
+	for _, c := range []struct {
+		desc      string
+		wb        []v1beta1.WorkspaceBinding
+		wantSteps []v1beta1.Step
+	}{{
+		desc: "no oci workspace",
+		wb: []v1beta1.WorkspaceBinding{{
+			Name:     "other",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		wantSteps: ts.Steps,
+	}, {
+		desc: "oci workspace pulled and pushed",
+		wb: []v1beta1.WorkspaceBinding{{
+			Name:    "source",
+			SubPath: "src",
+			OCI: &v1beta1.WorkspaceOCI{
+				Repository: "registry/workspaces",
+				From:       []string{"run-fetch", "run-lint"},
+				To:         "run-build",
+			},
+		}, {
+			Name:     "other",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		wantSteps: []v1beta1.Step{{Container: corev1.Container{
+			Name:         "artifact-pull-source-9l9zj",
+			Image:        "workspace-artifact",
+			Command:      []string{"/ko-app/workspace-artifact"},
+			Args:         []string{"-mode", "pull", "-ref", "registry/workspaces:run-fetch", "-path", "/tekton/workspace-artifacts/source"},
+			VolumeMounts: []corev1.VolumeMount{{Name: "ws-abcde", MountPath: "/tekton/workspace-artifacts/source"}},
+		}}, {Container: corev1.Container{
+			Name:         "artifact-pull-source-mz4c7",
+			Image:        "workspace-artifact",
+			Command:      []string{"/ko-app/workspace-artifact"},
+			Args:         []string{"-mode", "pull", "-ref", "registry/workspaces:run-lint", "-path", "/tekton/workspace-artifacts/source"},
+			VolumeMounts: []corev1.VolumeMount{{Name: "ws-abcde", MountPath: "/tekton/workspace-artifacts/source"}},
+		}}, {Container: corev1.Container{
+			Name:  "build",
+			Image: "golang",
+		}}, {Container: corev1.Container{
+			Name:         "artifact-push-source-mssqb",
+			Image:        "workspace-artifact",
+			Command:      []string{"/ko-app/workspace-artifact"},
+			Args:         []string{"-mode", "push", "-ref", "registry/workspaces:run-build", "-path", "/tekton/workspace-artifacts/source"},
+			VolumeMounts: []corev1.VolumeMount{{Name: "ws-abcde", MountPath: "/tekton/workspace-artifacts/source"}},
+		}}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			names.TestingSeed()
+			got := AddWorkspaceArtifactSteps("workspace-artifact", ts, c.wb, volumes)
+			if d := cmp.Diff(c.wantSteps, got.Steps); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/git"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// workspaceGitDir is the directory under which the git steps mount the volumes of the workspaces they clone into.
+const workspaceGitDir = "/tekton/workspace-git"
+
+// GetWorkspaceGitResourceName returns the resource name of the results of the git step of a workspace,
+// e.g. the fetched commit, in the status of the TaskRun.
+func GetWorkspaceGitResourceName(workspaceName string) string {
+	return fmt.Sprintf("workspaces.%s", workspaceName)
+}
+
+// AddWorkspaceGitSteps prepends to the steps of ts a step cloning the git repository of each workspace
+// binding that has one into the volume of the workspace. The steps mount the volume writable, even if the
+// workspace is read only, and write the fetched commit to the resources results of the TaskRun.
+func AddWorkspaceGitSteps(gitImage string, ts *v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, volumes map[string]corev1.Volume) (*v1beta1.TaskSpec, error) {
+	var gitSteps []v1beta1.Step
+	for _, w := range wb {
+		if w.Git == nil {
+			continue
+		}
+		mountPath := filepath.Join(workspaceGitDir, w.Name)
+		r := &git.Resource{
+			Name:       w.Name,
+			Type:       resourcev1alpha1.PipelineResourceTypeGit,
+			URL:        w.Git.URL,
+			Revision:   w.Git.Revision,
+			Refspec:    w.Git.Refspec,
+			Submodules: true,
+			Depth:      1,
+			SSLVerify:  true,
+			GitImage:   gitImage,
+		}
+		if w.Git.Depth != nil {
+			r.Depth = *w.Git.Depth
+		}
+		tm, err := r.GetInputTaskModifier(ts, mountPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range tm.GetStepsToPrepend() {
+			s.VolumeMounts = append(s.VolumeMounts, corev1.VolumeMount{
+				Name:      volumes[w.Name].Name,
+				MountPath: mountPath,
+				SubPath:   w.SubPath,
+			})
+			for i, e := range s.Env {
+				if e.Name == "TEKTON_RESOURCE_NAME" {
+					s.Env[i].Value = GetWorkspaceGitResourceName(w.Name)
+				}
+			}
+			gitSteps = append(gitSteps, s)
+		}
+	}
+	if len(gitSteps) == 0 {
+		return ts, nil
+	}
+
+	ts = ts.DeepCopy()
+	ts.Steps = append(gitSteps, ts.Steps...)
+	return ts, nil
+}
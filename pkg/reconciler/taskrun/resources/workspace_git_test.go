@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddWorkspaceGitSteps(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name:     "source",
+			ReadOnly: true,
+		}},
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name:  "build",
+			Image: "golang",
+		}}},
+	}
+	volumes := map[string]corev1.Volume{
+		"source": {Name: "ws-abcde"},
+	}
+	full := uint(0)
+
+	for _, c := range []struct {
+		desc      string
+		wb        []v1beta1.WorkspaceBinding
+		wantSteps []v1beta1.Step
+	}{{
+		desc: "no git workspace",
+		wb: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		wantSteps: ts.Steps,
+	}, {
+		desc: "git workspace",
+		wb: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			SubPath:  "src",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+			Git: &v1beta1.WorkspaceGit{
+				URL:      "https://github.com/tektoncd/pipeline",
+				Revision: "main",
+				Depth:    &full,
+			},
+		}},
+		wantSteps: []v1beta1.Step{{Container: corev1.Container{
+			Name:       "git-source-source-9l9zj",
+			Image:      "git-init",
+			Command:    []string{"/ko-app/git-init"},
+			Args:       []string{"-url", "https://github.com/tektoncd/pipeline", "-path", "/tekton/workspace-git/source", "-revision", "main", "-depth", "0"},
+			WorkingDir: "/workspace",
+			Env: []corev1.EnvVar{
+				{Name: "TEKTON_RESOURCE_NAME", Value: "workspaces.source"},
+				{Name: "HOME", Value: "/tekton/home"},
+			},
+			VolumeMounts: []corev1.VolumeMount{{Name: "ws-abcde", MountPath: "/tekton/workspace-git/source", SubPath: "src"}},
+		}}, {Container: corev1.Container{
+			Name:  "build",
+			Image: "golang",
+		}}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			names.TestingSeed()
+			got, err := AddWorkspaceGitSteps("git-init", ts, c.wb, volumes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(c.wantSteps, got.Steps); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -45,10 +45,12 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -74,6 +76,7 @@ type Reconciler struct {
 	entrypointCache   podconvert.EntrypointCache
 	metrics           *Recorder
 	pvcHandler        volumeclaim.PvcHandler
+	snapshotHandler   volumesnapshot.SnapshotHandler
 
 	snooze func(kmeta.Accessor, time.Duration)
 }
@@ -127,6 +130,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 			return cloudEventErr
 		}
 
+		// The PVCs of snapshotted workspaces are retained, so the snapshots can be cut from them after the TaskRun is done.
+		c.snapshotWorkspaces(ctx, tr)
+
+		if err := c.deleteWorkspacePVCs(ctx, tr); err != nil {
+			logger.Errorf("Failed to delete workspace PVCs for TaskRun %s: %v", tr.Name, err)
+			return err
+		}
+
 		pod, err := c.stopSidecars(ctx, tr)
 		if err != nil {
 			return err
@@ -143,6 +154,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 					logger.Warnf("Failed to log the metrics : %v", err)
 				}
 			}
+			err = metrics.StepResourceUsage(tr)
+			if err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
 			err = metrics.CloudEvents(tr)
 			if err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
@@ -198,6 +213,76 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// Emit events (only when ConditionSucceeded was changed)
 	return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 }
+
+// deleteWorkspacePVCs deletes the PVCs created from the volumeClaimTemplates of the done TaskRun
+// according to their retention policy, and records an event for each deleted PVC.
+func (c *Reconciler) deleteWorkspacePVCs(ctx context.Context, tr *v1beta1.TaskRun) error {
+	succeeded := tr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	deleted, err := c.pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, tr.Spec.Workspaces, tr.GetOwnerReference(), tr.Namespace, succeeded)
+	recorder := controller.GetEventRecorder(ctx)
+	for _, claimName := range deleted {
+		recorder.Eventf(tr, corev1.EventTypeNormal, volumeclaim.ReasonDeletedWorkspacePVC, "Deleted PersistentVolumeClaim %s", claimName)
+	}
+	return err
+}
+
+// snapshotWorkspaces takes VolumeSnapshots of the PVCs of the workspaces that ask for them once the TaskRun is done,
+// and records them in its status. A snapshot that can't be taken is reported with an event, without failing the
+// reconciliation, and taken on a later reconciliation, unless it can never be taken, e.g. because the cluster
+// doesn't serve VolumeSnapshots: the failure is then recorded in the status instead.
+func (c *Reconciler) snapshotWorkspaces(ctx context.Context, tr *v1beta1.TaskRun) {
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+	succeeded := tr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	taken := sets.NewString()
+	for _, s := range tr.Status.WorkspaceSnapshots {
+		taken.Insert(s.Name)
+	}
+	for _, wb := range applyVolumeClaimTemplates(tr.Spec.Workspaces, tr) {
+		if wb.Snapshot == nil || wb.PersistentVolumeClaim == nil || taken.Has(wb.Name) {
+			continue
+		}
+		if succeeded && wb.Snapshot.When != v1beta1.WorkspaceSnapshotAlways {
+			continue
+		}
+		name := volumesnapshot.GetVolumeSnapshotName(wb, tr.GetOwnerReference())
+		claimName := wb.PersistentVolumeClaim.ClaimName
+		// The snapshot of a cache that succeeded gets its labels, so that it can restore the cache once evicted.
+		var labels map[string]string
+		if succeeded {
+			pvc, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims(tr.Namespace).Get(ctx, claimName, metav1.GetOptions{})
+			switch {
+			case k8serrors.IsNotFound(err):
+				// the snapshot reports the missing PVC in its status
+			case err != nil:
+				logger.Errorf("Failed to get the PVC of workspace %s of TaskRun %s to snapshot: %v", wb.Name, tr.Name, err)
+				recorder.Eventf(tr, corev1.EventTypeWarning, volumesnapshot.ReasonCouldntCreateVolumeSnapshot, "Failed to snapshot workspace %s: %v", wb.Name, err)
+				continue
+			default:
+				labels = volumeclaim.CacheLabels(pvc.Labels)
+			}
+		}
+		if err := c.snapshotHandler.CreateVolumeSnapshot(ctx, name, claimName, wb.Snapshot.VolumeSnapshotClassName, labels, tr.GetOwnerReference(), tr.Namespace); err != nil {
+			logger.Errorf("Failed to snapshot workspace %s of TaskRun %s: %v", wb.Name, tr.Name, err)
+			recorder.Eventf(tr, corev1.EventTypeWarning, volumesnapshot.ReasonCouldntCreateVolumeSnapshot, "Failed to snapshot workspace %s: %v", wb.Name, err)
+			if volumesnapshot.IsPermanent(err) {
+				tr.Status.WorkspaceSnapshots = append(tr.Status.WorkspaceSnapshots, v1beta1.WorkspaceSnapshotStatus{
+					Name:                      wb.Name,
+					PersistentVolumeClaimName: claimName,
+					Message:                   err.Error(),
+				})
+			}
+			continue
+		}
+		recorder.Eventf(tr, corev1.EventTypeNormal, volumesnapshot.ReasonCreatedVolumeSnapshot, "Created VolumeSnapshot %s of PersistentVolumeClaim %s", name, claimName)
+		tr.Status.WorkspaceSnapshots = append(tr.Status.WorkspaceSnapshots, v1beta1.WorkspaceSnapshotStatus{
+			Name:                      wb.Name,
+			PersistentVolumeClaimName: claimName,
+			VolumeSnapshotName:        name,
+		})
+	}
+}
+
 func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1beta1.TaskRun) (*corev1.Pod, error) {
 	logger := logging.FromContext(ctx)
 	// do not continue without knowing the associated pod
@@ -335,6 +420,24 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if err := validateOverrides(rtr.TaskSpec, &tr.Spec); err != nil {
+		logger.Errorf("TaskRun %q overrides are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
+	if err := validateScriptParams(ctx, tr, rtr.TaskSpec); err != nil {
+		logger.Errorf("TaskRun %q script params are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+	// Warn about the params substituted into scripts once, before the Pod is created.
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil && tr.Status.PodName == "" {
+		if paths := v1beta1.ScriptsInterpolatingParams(rtr.TaskSpec, v1beta1.ScriptParamsAsEnv(ctx, tr.Annotations)); len(paths) > 0 {
+			recorder.Eventf(tr, corev1.EventTypeWarning, "ScriptParamInterpolation", "Params are substituted into the scripts of %s, so their values may inject code; pass them as environment variables instead", strings.Join(paths, ", "))
+		}
+	}
+
 	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to update taskrun %s with default workspace: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -346,13 +449,10 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
 		return nil, nil, controller.NewPermanentError(err)
 	}
-
-	if _, usesAssistant := tr.Annotations[workspace.AnnotationAffinityAssistantName]; usesAssistant {
-		if err := workspace.ValidateOnlyOnePVCIsUsed(tr.Spec.Workspaces); err != nil {
-			logger.Errorf("TaskRun %q workspaces incompatible with Affinity Assistant: %v", tr.Name, err)
-			tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
-			return nil, nil, controller.NewPermanentError(err)
-		}
+	if err := volumeclaim.ValidateCacheKeys(tr.Spec.Workspaces, taskSpec.Params); err != nil {
+		logger.Errorf("TaskRun %q workspaces are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
 	}
 
 	// Initialize the cloud events if at least a CloudEventResource is defined
@@ -413,10 +513,28 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 						fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
 				return controller.NewPermanentError(err)
 			}
+		}
+
+		if err := c.pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, tr.Spec.Workspaces, volumeclaim.TaskRunCacheKeyReplacements(tr), tr.Namespace); err != nil {
+			if volumeclaim.IsCacheTerminating(err) {
+				// retry once the terminating cache is gone
+				logger.Infof("Waiting for the cache PVC of TaskRun %s: %v", tr.Name, err)
+				return err
+			}
+			logger.Errorf("Failed to create cache PVC for TaskRun %s: %v", tr.Name, err)
+			tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
+				fmt.Errorf("Failed to create cache PVC for TaskRun %s workspaces correctly: %s",
+					fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
+			return controller.NewPermanentError(err)
+		}
 
-			taskRunWorkspaces := applyVolumeClaimTemplates(tr.Spec.Workspaces, tr.GetOwnerReference())
-			// This is used by createPod below. Changes to the Spec are not updated.
-			tr.Spec.Workspaces = taskRunWorkspaces
+		// This is used by createPod below. Changes to the Spec are not updated.
+		tr.Spec.Workspaces = applyVolumeClaimTemplates(tr.Spec.Workspaces, tr)
+
+		if err := c.resolveScriptRefs(ctx, tr, rtr); err != nil {
+			logger.Errorf("Failed to load the scripts of taskrun %q: %v", tr.Name, err)
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
+			return controller.NewPermanentError(err)
 		}
 
 		pod, err = c.createPod(ctx, tr, rtr)
@@ -594,6 +712,22 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 	return nil
 }
 
+// resolveScriptRefs loads the scripts the TaskRun's Steps refer to into the
+// resolved TaskSpec, and records their digests in the TaskRun's status.
+func (c *Reconciler) resolveScriptRefs(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) error {
+	getConfigMap := func(ctx context.Context, name string) (*corev1.ConfigMap, error) {
+		return c.KubeClientSet.CoreV1().ConfigMaps(tr.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	getBundleFile := resources.GetBundleFileFunc(ctx, c.KubeClientSet, tr.Spec.TaskRef, tr.Namespace, tr.Spec.ServiceAccountName)
+	ts, resolved, err := resources.ResolveScriptRefs(ctx, rtr.TaskSpec, getConfigMap, getBundleFile)
+	if err != nil {
+		return err
+	}
+	rtr.TaskSpec = ts
+	tr.Status.ResolvedScripts = resolved
+	return nil
+}
+
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
@@ -633,6 +767,13 @@ func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	if len(ts.Params) > 0 {
 		defaults = append(defaults, ts.Params...)
 	}
+	// Pass the params referenced in scripts as environment variables, if
+	// enabled.
+	var scriptParamEnv resources.ScriptParamEnv
+	if v1beta1.ScriptParamsAsEnv(ctx, tr.Annotations) {
+		ts, scriptParamEnv = resources.ApplyParametersAsScriptEnv(ts, tr, defaults...)
+	}
+
 	// Apply parameter substitution from the taskrun.
 	ts = resources.ApplyParameters(ts, tr, defaults...)
 
@@ -652,17 +793,34 @@ func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	// Apply task result substitution
 	ts = resources.ApplyTaskResults(ts)
 
+	// Apply path substitutions for the legacy credentials helper (aka "creds-init")
+	ts = resources.ApplyCredentialsPath(ts, pipeline.CredsDir)
+
 	ts, err = workspace.Apply(*ts, tr.Spec.Workspaces, workspaceVolumes)
 	if err != nil {
 		logger.Errorf("Failed to create a pod for taskrun: %s due to workspace error %v", tr.Name, err)
 		return nil, err
 	}
 
+	// Add the values of the params referenced in scripts now that no more
+	// variables are substituted, so that they are passed verbatim
+	ts = resources.AddScriptParamEnv(ts, scriptParamEnv)
+
+	// Pull and push the workspaces that are handed off through OCI artifacts
+	ts = resources.AddWorkspaceArtifactSteps(c.Images.WorkspaceArtifactImage, ts, tr.Spec.Workspaces, workspaceVolumes)
+
+	// Clone the git repositories the workspaces are pre-populated from
+	ts, err = resources.AddWorkspaceGitSteps(c.Images.GitImage, ts, tr.Spec.Workspaces, workspaceVolumes)
+	if err != nil {
+		logger.Errorf("Failed to create a pod for taskrun: %s due to workspace git error %v", tr.Name, err)
+		return nil, err
+	}
+
 	// Check if the HOME env var of every Step should be set to /tekton/home.
 	shouldOverrideHomeEnv := podconvert.ShouldOverrideHomeEnv(ctx)
 
-	// Apply path substitutions for the legacy credentials helper (aka "creds-init")
-	ts = resources.ApplyCredentialsPath(ts, pipeline.CredsDir)
+	// Apply the TaskRun's overrides of the Steps and Sidecars
+	ts = resources.ApplyOverrides(ts, tr.Spec.StepOverrides, tr.Spec.SidecarOverrides)
 
 	podbuilder := podconvert.Builder{
 		Images:          c.Images,
@@ -670,12 +828,17 @@ func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		EntrypointCache: c.entrypointCache,
 		OverrideHomeEnv: shouldOverrideHomeEnv,
 	}
+	podTemplate := pod.MergePodTemplateWithDefault(tr.Spec.PodTemplate, config.FromContextOrDefaults(ctx).Defaults.DefaultPodTemplate)
 	pod, err := podbuilder.Build(ctx, tr, *ts)
 	if err != nil {
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
 	}
 
 	pod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err == nil {
+		// Record the pod template the Pod was built with
+		tr.Status.PodTemplate = podTemplate.DeepCopy()
+	}
 	if err == nil && willOverwritePodSetAffinity(tr) {
 		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
 			recorder.Eventf(tr, corev1.EventTypeWarning, "PodAffinityOverwrite", "Pod template affinity is overwritten by affinity assistant for pod %q", pod.Name)
@@ -753,21 +916,30 @@ func updateStoppedSidecarStatus(ctx context.Context, pod *corev1.Pod, tr *v1beta
 	return nil
 }
 
-// applyVolumeClaimTemplates and return WorkspaceBindings were templates is translated to PersistentVolumeClaims
-func applyVolumeClaimTemplates(workspaceBindings []v1beta1.WorkspaceBinding, owner metav1.OwnerReference) []v1beta1.WorkspaceBinding {
+// applyVolumeClaimTemplates and return WorkspaceBindings were templates and caches are translated to PersistentVolumeClaims
+func applyVolumeClaimTemplates(workspaceBindings []v1beta1.WorkspaceBinding, tr *v1beta1.TaskRun) []v1beta1.WorkspaceBinding {
 	taskRunWorkspaceBindings := make([]v1beta1.WorkspaceBinding, 0, len(workspaceBindings))
 	for _, wb := range workspaceBindings {
-		if wb.VolumeClaimTemplate == nil {
+		var claimName string
+		switch {
+		case wb.VolumeClaimTemplate != nil:
+			claimName = volumeclaim.GetPersistentVolumeClaimName(wb.VolumeClaimTemplate, wb, tr.GetOwnerReference())
+		case wb.Cache != nil:
+			claimName = volumeclaim.GetCachePersistentVolumeClaimName(wb, volumeclaim.TaskRunCacheKeyReplacements(tr))
+		default:
 			taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, wb)
 			continue
 		}
 
 		// apply template
 		b := v1beta1.WorkspaceBinding{
-			Name:    wb.Name,
-			SubPath: wb.SubPath,
+			Name:     wb.Name,
+			SubPath:  wb.SubPath,
+			ReadOnly: wb.ReadOnly,
+			Snapshot: wb.Snapshot,
+			Git:      wb.Git,
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb.VolumeClaimTemplate, wb, owner),
+				ClaimName: claimName,
 			},
 		}
 		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, b)
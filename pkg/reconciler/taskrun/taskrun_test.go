@@ -40,6 +40,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
 	"github.com/tektoncd/pipeline/pkg/version"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test"
@@ -56,6 +57,7 @@ import (
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	cminformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -85,9 +87,11 @@ var (
 		KubeconfigWriterImage:    "override-with-kubeconfig-writer:latest",
 		ShellImage:               "busybox",
 		GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
+		AwsCLIImage:              "docker.io/amazon/aws-cli",
 		BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+		WorkspaceArtifactImage:   "override-with-workspace-artifact-image:latest",
 	}
 	ignoreLastTransitionTime = cmpopts.IgnoreTypes(apis.Condition{}.LastTransitionTime.Inner.Time)
 	// Pods are created with a random 5-character suffix that we want to
@@ -1662,6 +1666,247 @@ func TestReconcile_SetsStartTime(t *testing.T) {
 	}
 }
 
+func TestReconcile_ScriptRefs(t *testing.T) {
+	scripts := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "foo"},
+		Data:       map[string]string{"build.sh": "make $(params.target)"},
+	}
+	for _, tc := range []struct {
+		name       string
+		key        string
+		wantScript string
+		wantDigest string
+		wantReason string
+	}{{
+		name:       "configmap script",
+		key:        "build.sh",
+		wantScript: "make all",
+		// echo -n 'make $(params.target)' | sha256sum
+		wantDigest: "sha256:80398e6982f9aa1ac5bfd387803e313e837d64433476a6274f830d27523692ad",
+	}, {
+		name:       "missing configmap key",
+		key:        "missing.sh",
+		wantReason: podconvert.ReasonFailedResolution,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-script-ref", Namespace: "foo"},
+				Spec: v1beta1.TaskRunSpec{
+					Params: []v1beta1.Param{{Name: "target", Value: *v1beta1.NewArrayOrString("all")}},
+					TaskSpec: &v1beta1.TaskSpec{
+						Params: []v1beta1.ParamSpec{{Name: "target", Type: v1beta1.ParamTypeString}},
+						Steps: []v1beta1.Step{{
+							Container: corev1.Container{Name: "build", Image: "foo"},
+							ScriptRef: &v1beta1.ScriptRef{
+								ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+									Key:                  tc.key,
+								},
+							},
+						}},
+					},
+				},
+			}
+			d := test.Data{
+				TaskRuns:   []*v1beta1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{scripts},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantReason == "" && err != nil {
+				t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			if tc.wantReason != "" {
+				condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantReason {
+					t.Errorf("Expected TaskRun to fail with reason %q but got condition %v", tc.wantReason, condition)
+				}
+				return
+			}
+
+			wantResolved := []v1beta1.ResolvedScript{{
+				Name:      "build",
+				ScriptRef: *taskRun.Spec.TaskSpec.Steps[0].ScriptRef,
+				Digest:    tc.wantDigest,
+			}}
+			if d := cmp.Diff(wantResolved, newTr.Status.ResolvedScripts); d != "" {
+				t.Errorf("TaskRun resolved scripts %s", diff.PrintWantGot(d))
+			}
+			pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, newTr.Status.PodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to fetch build pod: %v", err)
+			}
+			var placed string
+			for _, c := range pod.Spec.InitContainers {
+				if c.Name == "place-scripts" {
+					placed = c.Args[1]
+				}
+			}
+			if !strings.Contains(placed, tc.wantScript) {
+				t.Errorf("expected the scripts placed in the Pod to contain %q, got %q", tc.wantScript, placed)
+			}
+		})
+	}
+}
+
+func TestReconcile_Overrides(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		stepName         string
+		computeResources *corev1.ResourceRequirements
+		wantReason       string
+	}{{
+		name:     "override existing step",
+		stepName: "build",
+	}, {
+		name:       "override unknown step",
+		stepName:   "missing",
+		wantReason: podconvert.ReasonFailedValidation,
+	}, {
+		name:     "override the resources of a step of a TaskRun with computeResources",
+		stepName: "build",
+		computeResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		wantReason: podconvert.ReasonFailedValidation,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-overrides", Namespace: "foo"},
+				Spec: v1beta1.TaskRunSpec{
+					TaskSpec: &v1beta1.TaskSpec{
+						Steps: []v1beta1.Step{{Container: corev1.Container{
+							Name:    "build",
+							Image:   "foo",
+							Command: []string{"make"},
+							Env:     []corev1.EnvVar{{Name: "FOO", Value: "foo"}},
+						}}},
+					},
+					StepOverrides: []v1beta1.TaskRunStepOverride{{
+						Name: tc.stepName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+						Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+					}},
+					ComputeResources: tc.computeResources,
+				},
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantReason == "" && err != nil {
+				t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			if tc.wantReason != "" {
+				condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantReason {
+					t.Errorf("Expected TaskRun to fail with reason %q but got condition %v", tc.wantReason, condition)
+				}
+				return
+			}
+
+			pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, newTr.Status.PodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to fetch build pod: %v", err)
+			}
+			step := pod.Spec.Containers[0]
+			if got := step.Resources.Requests[corev1.ResourceMemory]; got.Cmp(resource.MustParse("1Gi")) != 0 {
+				t.Errorf("expected the step to request 1Gi of memory, got %s", got.String())
+			}
+			var foo string
+			for _, e := range step.Env {
+				if e.Name == "FOO" {
+					foo = e.Value
+				}
+			}
+			if foo != "bar" {
+				t.Errorf("expected the step's FOO env var to be overridden with %q, got %q", "bar", foo)
+			}
+		})
+	}
+}
+
+func TestReconcile_RecordsPodTemplate(t *testing.T) {
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-pod-template", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:    "build",
+					Image:   "foo",
+					Command: []string{"make"},
+				}}},
+			},
+			PodTemplate: &pod.Template{NodeSelector: map[string]string{"disktype": "ssd"}},
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"default-pod-template": "tolerations: [{ key: 'dedicated', operator: 'Exists' }]",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+
+	want := &pod.Template{
+		NodeSelector: map[string]string{"disktype": "ssd"},
+		Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+	}
+	if d := cmp.Diff(want, newTr.Status.PodTemplate); d != "" {
+		t.Errorf("TaskRun pod template %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestReconcile_DoesntChangeStartTime(t *testing.T) {
 	startTime := time.Date(2000, 1, 1, 1, 1, 1, 1, time.UTC)
 	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
@@ -1698,8 +1943,25 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 	withWrongRef := tb.TaskRun("taskrun-with-wrong-ref", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
 		tb.TaskRunTaskRef("taskrun-with-wrong-ref", tb.TaskRefKind(v1beta1.ClusterTaskKind)),
 	))
-	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef}
-	tasks := []*v1beta1.Task{simpleTask}
+	collidingScriptParamsTask := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "colliding-script-params", Namespace: "foo"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "foo-bar", Type: v1beta1.ParamTypeString}, {Name: "foo_bar", Type: v1beta1.ParamTypeString}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "busybox"},
+				Script:    "echo $(params.foo-bar) $(params.foo_bar)",
+			}},
+		},
+	}
+	withCollidingScriptParams := tb.TaskRun("taskrun-with-colliding-script-params", tb.TaskRunNamespace("foo"),
+		tb.TaskRunAnnotation(v1beta1.ScriptParamsAsEnvAnnotation, "true"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef(collidingScriptParamsTask.Name),
+			tb.TaskRunParam("foo-bar", "a"),
+			tb.TaskRunParam("foo_bar", "b"),
+		))
+	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef, withCollidingScriptParams}
+	tasks := []*v1beta1.Task{simpleTask, collidingScriptParamsTask}
 
 	d := test.Data{
 		TaskRuns: taskRuns,
@@ -1729,6 +1991,15 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			"Warning Failed",
 			"Warning InternalError",
 		},
+	}, {
+		name:    "task run with params passed to scripts as the same environment variable",
+		taskRun: withCollidingScriptParams,
+		reason:  podconvert.ReasonFailedValidation,
+		wantEvents: []string{
+			"Normal Started",
+			"Warning Failed",
+			"Warning InternalError",
+		},
 	}}
 
 	for _, tc := range testcases {
@@ -1970,6 +2241,187 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+// TestReconcileScriptParamInterpolation tests that a TaskRun substituting params into the scripts of its Task is
+// warned about when it's validated, and isn't when the params are passed as environment variables.
+func TestReconcileScriptParamInterpolation(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "script-params", Namespace: "foo"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "title", Type: v1beta1.ParamTypeString}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "busybox"},
+				Script:    `echo "$(params.title)"`,
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		name       string
+		asEnv      string
+		wantEvents []string
+	}{{
+		name:  "params substituted into scripts",
+		asEnv: "false",
+		wantEvents: []string{
+			"Normal Started",
+			"Warning ScriptParamInterpolation Params are substituted into the scripts of steps\\[0\\]",
+			"Normal Running",
+		},
+	}, {
+		name:  "params passed as environment variables",
+		asEnv: "true",
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-script-params", tb.TaskRunNamespace("foo"),
+				tb.TaskRunAnnotation(v1beta1.ScriptParamsAsEnvAnnotation, tc.asEnv),
+				tb.TaskRunSpec(tb.TaskRunTaskRef(task.Name), tb.TaskRunParam("title", "hello")))
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{task},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(testAssets.Ctx, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: taskRun.Namespace},
+			}, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				t.Errorf("expected no error. Got error %v", err)
+			}
+			if err := checkEvents(t, testAssets.Recorder, tc.name, tc.wantEvents); err != nil {
+				t.Errorf(err.Error())
+			}
+			tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting the pod of the taskrun: %v", err)
+			}
+			hasEnv := false
+			for _, e := range pod.Spec.Containers[0].Env {
+				if e.Name == "PARAMS_TITLE" && e.Value == "hello" {
+					hasEnv = true
+				}
+			}
+			if want := tc.asEnv == "true"; hasEnv != want {
+				t.Errorf("Expected the step to have the PARAMS_TITLE environment variable: %t, got env %v", want, pod.Spec.Containers[0].Env)
+			}
+		})
+	}
+}
+
+// TestReconcileStepProgress tests that the progress a step of a running TaskRun reports in its termination
+// message is set in the status of the step, without reading the logs of the Pod.
+func TestReconcileStepProgress(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-step-progress", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name: pod.Spec.Containers[0].Name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `[{"key":"Progress","value":"42%","type":"InternalTektonResult"}]`,
+			}},
+		}},
+	}
+	taskRun.Status = v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: pod.Name,
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{"enable-step-progress": "true"},
+		}},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if len(newTr.Status.Steps) != 1 || newTr.Status.Steps[0].Progress != "42%" {
+		t.Errorf("Expected the progress of the step to be set, got %+v", newTr.Status.Steps)
+	}
+	for _, action := range clients.Kube.Actions() {
+		if action.GetSubresource() == "log" {
+			t.Errorf("Expected the logs of the Pod not to be read, got %v", action)
+		}
+	}
+}
+
+// TestReconcileRunningStepProgress tests that the progress a running step publishes in an annotation of the Pod
+// is set in the status of the step.
+func TestReconcileRunningStepProgress(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-running-step-progress", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Spec.Containers[0].Args = append([]string{"-progress_annotation", "tekton.dev/step-progress-0"}, pod.Spec.Containers[0].Args...)
+	pod.Annotations["tekton.dev/step-progress-0"] = "suite 2/4"
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  pod.Spec.Containers[0].Name,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}},
+	}
+	taskRun.Status = v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: pod.Name,
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{"enable-step-progress": "true"},
+		}},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if len(newTr.Status.Steps) != 1 || newTr.Status.Steps[0].Progress != "suite 2/4" {
+		t.Errorf("Expected the progress of the running step to be set, got %+v", newTr.Status.Steps)
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskSt := &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -2004,6 +2456,132 @@ func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	}
 }
 
+// TestReconcileOnCompletedTaskRunWithWorkspaceSnapshot tests that a VolumeSnapshot of the PVC of a workspace is
+// taken and recorded in the status of a completed TaskRun according to the snapshot policy of the workspace.
+func TestReconcileOnCompletedTaskRunWithWorkspaceSnapshot(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		status    corev1.ConditionStatus
+		when      v1beta1.WorkspaceSnapshotPolicy
+		forbidden bool
+		// cache binds the workspace to the PVC of a cache, whose labels are given to the snapshots of
+		// the TaskRuns that succeeded.
+		cache        bool
+		wantSnapshot bool
+		wantLabels   map[string]string
+	}{{
+		name:         "failed",
+		status:       corev1.ConditionFalse,
+		wantSnapshot: true,
+	}, {
+		name:         "failed-cache",
+		status:       corev1.ConditionFalse,
+		cache:        true,
+		wantSnapshot: true,
+	}, {
+		name:         "succeeded-always-cache",
+		status:       corev1.ConditionTrue,
+		when:         v1beta1.WorkspaceSnapshotAlways,
+		cache:        true,
+		wantSnapshot: true,
+		wantLabels:   map[string]string{volumeclaim.LabelCache: "go-modules", volumeclaim.LabelCacheKey: "abc"},
+	}, {
+		name:   "succeeded",
+		status: corev1.ConditionTrue,
+	}, {
+		name:         "succeeded-always",
+		status:       corev1.ConditionTrue,
+		when:         v1beta1.WorkspaceSnapshotAlways,
+		wantSnapshot: true,
+	}, {
+		name:      "forbidden",
+		status:    corev1.ConditionFalse,
+		forbidden: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-" + tc.name, Namespace: "foo"},
+				Spec: v1beta1.TaskRunSpec{
+					TaskRef: &v1beta1.TaskRef{Name: simpleTask.Name},
+					Workspaces: []v1beta1.WorkspaceBinding{{
+						Name: "source",
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "source-pvc",
+						},
+						Snapshot: &v1beta1.WorkspaceSnapshot{When: tc.when},
+					}},
+				},
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: tc.status}},
+					},
+				},
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+			if tc.forbidden {
+				clients.Dynamic.PrependReactor("create", "volumesnapshots", func(action ktesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8sapierrors.NewForbidden(volumesnapshot.VolumeSnapshotResource.GroupResource(), "", errors.New("not allowed"))
+				})
+			}
+			if tc.cache {
+				if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Create(testAssets.Ctx, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "source-pvc",
+						Namespace: "foo",
+						Labels:    map[string]string{volumeclaim.LabelCache: "go-modules", volumeclaim.LabelCacheKey: "abc"},
+					},
+				}, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected completed TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			snapshotName := "test-taskrun-" + tc.name + "-source"
+			snapshot, err := clients.Dynamic.Resource(volumesnapshot.VolumeSnapshotResource).Namespace("foo").Get(testAssets.Ctx, snapshotName, metav1.GetOptions{})
+			var want []v1beta1.WorkspaceSnapshotStatus
+			if tc.forbidden {
+				// The failure is recorded so that the snapshot isn't retried
+				if len(newTr.Status.WorkspaceSnapshots) != 1 || newTr.Status.WorkspaceSnapshots[0].VolumeSnapshotName != "" || newTr.Status.WorkspaceSnapshots[0].Message == "" {
+					t.Errorf("Expected the failed snapshot to be recorded with a message, got %v", newTr.Status.WorkspaceSnapshots)
+				}
+				return
+			}
+			if tc.wantSnapshot {
+				if err != nil {
+					t.Fatalf("Expected the VolumeSnapshot %s to be created: %v", snapshotName, err)
+				}
+				if d := cmp.Diff(tc.wantLabels, snapshot.GetLabels()); d != "" {
+					t.Errorf("Unexpected VolumeSnapshot labels %s", diff.PrintWantGot(d))
+				}
+				want = []v1beta1.WorkspaceSnapshotStatus{{
+					Name:                      "source",
+					PersistentVolumeClaimName: "source-pvc",
+					VolumeSnapshotName:        snapshotName,
+				}}
+			} else if !k8sapierrors.IsNotFound(err) {
+				t.Errorf("Expected no VolumeSnapshot to be created, got %v", err)
+			}
+			if d := cmp.Diff(want, newTr.Status.WorkspaceSnapshots); d != "" {
+				t.Errorf("Unexpected workspace snapshots %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-cancelled",
 		tb.TaskRunNamespace("foo"),
@@ -2192,7 +2770,7 @@ func TestHandlePodCreationError(t *testing.T) {
 		cloudEventClient: testAssets.Clients.CloudEvents,
 		metrics:          nil, // Not used
 		entrypointCache:  nil, // Not used
-		pvcHandler:       volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler:       volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger, testAssets.Informers.Pod.Lister(), testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), volumesnapshot.NewSnapshotHandler(testAssets.Clients.Dynamic, testAssets.Logger)),
 	}
 
 	testcases := []struct {
@@ -2864,14 +3442,15 @@ func TestReconcileTaskResourceResolutionAndValidation(t *testing.T) {
 	}
 }
 
-// TestReconcileWithWorkspacesIncompatibleWithAffinityAssistant tests that a TaskRun used with an associated
-// Affinity Assistant is validated and that the validation fails for a TaskRun that is incompatible with
-// Affinity Assistant; e.g. using more than one PVC-backed workspace.
-func TestReconcileWithWorkspacesIncompatibleWithAffinityAssistant(t *testing.T) {
+// TestReconcileWithMultiplePVCWorkspacesAndAffinityAssistant tests that a TaskRun used with an associated
+// Affinity Assistant can use more than one PVC-backed workspace, since the Affinity Assistant of a
+// PipelineRun mounts all of its PVC workspaces.
+func TestReconcileWithMultiplePVCWorkspacesAndAffinityAssistant(t *testing.T) {
 	taskWithTwoWorkspaces := tb.Task("test-task-two-workspaces", tb.TaskNamespace("foo"),
 		tb.TaskSpec(
 			tb.TaskWorkspace("ws1", "task workspace", "", true),
 			tb.TaskWorkspace("ws2", "another workspace", "", false),
+			tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
 		))
 	taskRun := tb.TaskRun("taskrun-with-two-workspaces", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
 		tb.TaskRunTaskRef(taskWithTwoWorkspaces.Name, tb.TaskRefAPIVersion("a1")),
@@ -2920,15 +3499,77 @@ func TestReconcileWithWorkspacesIncompatibleWithAffinityAssistant(t *testing.T)
 		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
 	}
 
-	if len(ttt.Status.Conditions) != 1 {
-		t.Errorf("unexpected number of Conditions, expected 1 Condition")
+	if ttt.Status.PodName == "" {
+		t.Errorf("expected a Pod to be created for TaskRun %s, got conditions %v", taskRun.Name, ttt.Status.Conditions)
 	}
+}
 
-	for _, cond := range ttt.Status.Conditions {
-		if cond.Reason != podconvert.ReasonFailedValidation {
-			t.Errorf("unexpected Reason on the Condition, expected: %s, got: %s", podconvert.ReasonFailedValidation, cond.Reason)
+// TestReconcileWithOCIWorkspace tests that the Pod of a TaskRun with an OCI workspace pulls the workspace
+// before the steps, and pushes it after them.
+func TestReconcileWithOCIWorkspace(t *testing.T) {
+	taskWithWorkspace := tb.Task("test-task-with-workspace", tb.TaskNamespace("foo"),
+		tb.TaskSpec(
+			tb.TaskWorkspace("ws1", "a test task workspace", "", false),
+			tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
+		))
+	taskRun := tb.TaskRun("test-taskrun-oci-workspace", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef(taskWithWorkspace.Name, tb.TaskRefAPIVersion("a1")),
+	))
+	taskRun.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+		Name: "ws1",
+		OCI: &v1beta1.WorkspaceOCI{
+			Repository: "registry/workspaces",
+			From:       []string{"run-fetch"},
+			To:         "run-build",
+		},
+	}}
+
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{taskWithWorkspace},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+	}
+	names.TestingSeed()
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun))
+
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected a Pod to be created for TaskRun %s: %v", taskRun.Name, err)
+	}
+
+	var got []string
+	for _, c := range pod.Spec.Containers {
+		got = append(got, c.Name)
+	}
+	want := []string{"step-artifact-pull-ws1-mz4c7", "step-simple-step", "step-artifact-push-ws1-mssqb"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected containers %s", diff.PrintWantGot(d))
+	}
+	found := false
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == "ws-9l9zj" {
+			found = true
+			if v.EmptyDir == nil {
+				t.Errorf("expected the OCI workspace to be an emptyDir, got %v", v.VolumeSource)
+			}
 		}
 	}
+	if !found {
+		t.Errorf("expected the Pod to have the volume of the OCI workspace, got %v", pod.Spec.Volumes)
+	}
 }
 
 // TestReconcileWorkspaceWithVolumeClaimTemplate tests a reconcile of a TaskRun that has
@@ -2993,6 +3634,203 @@ func TestReconcileWorkspaceWithVolumeClaimTemplate(t *testing.T) {
 	}
 }
 
+// TestReconcileWorkspaceWithVolumeClaimTemplateAndGit tests that the git repository of a workspace bound
+// to a volumeClaimTemplate is cloned into the PersistentVolumeClaim created from the template.
+func TestReconcileWorkspaceWithVolumeClaimTemplateAndGit(t *testing.T) {
+	workspaceName := "ws1"
+	taskWithWorkspace := tb.Task("test-task-with-workspace", tb.TaskNamespace("foo"),
+		tb.TaskSpec(
+			tb.TaskWorkspace(workspaceName, "a test task workspace", "", false),
+			tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
+		))
+	taskRun := tb.TaskRun("test-taskrun-workspace-git", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef(taskWithWorkspace.Name),
+	))
+	taskRun.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+		Name: workspaceName,
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+		},
+		Git: &v1beta1.WorkspaceGit{URL: "https://github.com/tektoncd/pipeline"},
+	}}
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{taskWithWorkspace},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+	}
+	names.TestingSeed()
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the Pod of TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if len(pod.Spec.Containers) != 2 {
+		t.Fatalf("expected a step cloning the git repository before the Task's step, got containers %v", pod.Spec.Containers)
+	}
+	clone := pod.Spec.Containers[0]
+	if !strings.Contains(strings.Join(clone.Args, " "), "-url https://github.com/tektoncd/pipeline") {
+		t.Errorf("expected the first step to clone the git repository, got args %v", clone.Args)
+	}
+	var claimName string
+	for _, m := range clone.VolumeMounts {
+		for _, v := range pod.Spec.Volumes {
+			if v.Name == m.Name && v.PersistentVolumeClaim != nil {
+				claimName = v.PersistentVolumeClaim.ClaimName
+			}
+		}
+	}
+	if !strings.HasPrefix(claimName, "mypvc-") {
+		t.Errorf("expected the git repository to be cloned into the PVC created from the volumeClaimTemplate, got %q", claimName)
+	}
+}
+
+// TestReconcileReadOnlyWorkspaceWithVolumeClaimTemplate tests that a workspace bound read-only to a
+// volumeClaimTemplate is mounted read-only in the steps.
+func TestReconcileReadOnlyWorkspaceWithVolumeClaimTemplate(t *testing.T) {
+	workspaceName := "ws1"
+	taskWithWorkspace := tb.Task("test-task-with-workspace", tb.TaskNamespace("foo"),
+		tb.TaskSpec(
+			tb.TaskWorkspace(workspaceName, "a test task workspace", "/ws", false),
+			tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
+		))
+	taskRun := tb.TaskRun("test-taskrun-workspace-read-only", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+		tb.TaskRunTaskRef(taskWithWorkspace.Name),
+	))
+	taskRun.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+		Name:     workspaceName,
+		ReadOnly: true,
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+		},
+	}}
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{taskWithWorkspace},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the Pod of TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	found := false
+	for _, m := range pod.Spec.Containers[0].VolumeMounts {
+		if m.MountPath == "/ws" {
+			found = true
+			if !m.ReadOnly {
+				t.Errorf("expected the workspace to be mounted read-only, got %+v", m)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected the workspace to be mounted at /ws, got %v", pod.Spec.Containers[0].VolumeMounts)
+	}
+}
+
+// TestReconcileCacheWorkspaceWithParamDefault tests that the key of a cache is resolved with the default
+// of a param that the TaskRun doesn't set, and that a key referencing an undeclared param fails the TaskRun.
+func TestReconcileCacheWorkspaceWithParamDefault(t *testing.T) {
+	taskWithCache := tb.Task("test-task-with-cache", tb.TaskNamespace("foo"),
+		tb.TaskSpec(
+			tb.TaskParam("go-sum-hash", v1beta1.ParamTypeString, tb.ParamSpecDefault("abc")),
+			tb.TaskWorkspace("cache", "a test task workspace", "", false),
+			tb.Step("foo", tb.StepName("simple-step"), tb.StepCommand("/mycmd")),
+		))
+	for _, tc := range []struct {
+		name       string
+		key        string
+		wantFailed bool
+	}{{
+		name: "declared param",
+		key:  "go-$(params.go-sum-hash)",
+	}, {
+		name:       "undeclared param",
+		key:        "go-$(params.go-sum)",
+		wantFailed: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-cache", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
+				tb.TaskRunTaskRef(taskWithCache.Name),
+			))
+			taskRun.Spec.Workspaces = []v1beta1.WorkspaceBinding{{
+				Name: "cache",
+				Cache: &v1beta1.WorkspaceCache{
+					Key: tc.key,
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{Name: "go-modules"},
+					},
+				},
+			}}
+			d := test.Data{
+				Tasks:    []*v1beta1.Task{taskWithCache},
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(testAssets.Ctx, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantFailed {
+				if err == nil {
+					t.Fatal("expected an error reconciling a TaskRun whose cache key references an undeclared param")
+				}
+				tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+				}
+				condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Reason != podconvert.ReasonFailedValidation {
+					t.Errorf("expected the TaskRun to fail validation, got condition %v", condition)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+			claimName := volumeclaim.GetCachePersistentVolumeClaimName(taskRun.Spec.Workspaces[0], map[string]string{"params.go-sum-hash": "abc"})
+			if _, err := clients.Kube.CoreV1().PersistentVolumeClaims(taskRun.Namespace).Get(testAssets.Ctx, claimName, metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the cache PVC %s keyed with the default of the param to exist, got %v", claimName, err)
+			}
+		})
+	}
+}
+
 func TestFailTaskRun(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -3242,7 +4080,7 @@ func TestFailTaskRun(t *testing.T) {
 				cloudEventClient: testAssets.Clients.CloudEvents,
 				metrics:          nil, // Not used
 				entrypointCache:  nil, // Not used
-				pvcHandler:       volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+				pvcHandler:       volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger, testAssets.Informers.Pod.Lister(), testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), volumesnapshot.NewSnapshotHandler(testAssets.Clients.Dynamic, testAssets.Logger)),
 			}
 
 			err := c.failTaskRun(context.Background(), tc.taskRun, tc.reason, tc.message)
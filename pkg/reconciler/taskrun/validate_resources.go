@@ -17,6 +17,7 @@ limitations under the License.
 package taskrun
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -128,3 +129,56 @@ func ValidateResolvedTaskResources(params []v1beta1.Param, rtr *resources.Resolv
 
 	return nil
 }
+
+// validateScriptParams validates that the params referenced in the scripts
+// of the resolved TaskSpec can be passed as environment variables, if the
+// TaskRun passes them so.
+func validateScriptParams(ctx context.Context, tr *v1beta1.TaskRun, ts *v1beta1.TaskSpec) error {
+	if !v1beta1.ScriptParamsAsEnv(ctx, tr.Annotations) {
+		return nil
+	}
+	if err := v1beta1.ValidateScriptParamsAsEnv(ts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateOverrides validates that the Steps and Sidecars the TaskRun
+// overrides exist in the resolved TaskSpec.
+func validateOverrides(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) error {
+	stepNames := make([]string, 0, len(ts.Steps))
+	for _, s := range ts.Steps {
+		stepNames = append(stepNames, s.Name)
+	}
+	overridden := make([]string, 0, len(trs.StepOverrides))
+	for _, o := range trs.StepOverrides {
+		overridden = append(overridden, o.Name)
+		if len(o.Resources.Requests) == 0 && len(o.Resources.Limits) == 0 {
+			continue
+		}
+		// computeResources replace the resources of all the Steps, so
+		// they would silently discard the overridden ones.
+		if ts.ComputeResources != nil {
+			return fmt.Errorf("the resources of Step %q can't be overridden because the Task specifies computeResources", o.Name)
+		}
+		if trs.ComputeResources != nil {
+			return fmt.Errorf("the resources of Step %q can't be overridden because the TaskRun specifies computeResources", o.Name)
+		}
+	}
+	if missing := list.DiffLeft(overridden, stepNames); len(missing) > 0 {
+		return fmt.Errorf("TaskRun's stepOverrides didn't match any Steps in Task: %s", missing)
+	}
+
+	sidecarNames := make([]string, 0, len(ts.Sidecars))
+	for _, s := range ts.Sidecars {
+		sidecarNames = append(sidecarNames, s.Name)
+	}
+	overridden = make([]string, 0, len(trs.SidecarOverrides))
+	for _, o := range trs.SidecarOverrides {
+		overridden = append(overridden, o.Name)
+	}
+	if missing := list.DiffLeft(overridden, sidecarNames); len(missing) > 0 {
+		return fmt.Errorf("TaskRun's sidecarOverrides didn't match any Sidecars in Task: %s", missing)
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// LabelCache is the label identifying the caches of a cache workspace that are evicted together.
+	LabelCache = pipeline.GroupName + "/cache"
+	// LabelCacheKey is the label holding the hash of the key of a cache.
+	LabelCacheKey = pipeline.GroupName + "/cache-key"
+	// AnnotationCacheLastUsed is the annotation holding the last time a cache was used, in RFC3339 format.
+	AnnotationCacheLastUsed = pipeline.GroupName + "/cache-last-used"
+)
+
+// ErrCacheTerminating is returned when the PVC of a cache is being deleted, so that it can't be reused
+// nor created again until it is gone.
+var ErrCacheTerminating = errors.New("cache PVC is being deleted")
+
+// IsCacheTerminating returns true if the error is caused by a cache PVC being deleted.
+func IsCacheTerminating(err error) bool {
+	return errors.Is(err, ErrCacheTerminating)
+}
+
+// CreatePersistentVolumeClaimsForCaches creates, or marks as used, the PVC of each cache workspace for the key
+// resolved with the replacements, and then evicts the caches with the same name according to their maxAge and
+// maxEntries. The caches whose key references results that are not in the replacements yet are skipped.
+// Cache PVCs are not owned by the run, so that they can be reused by the next runs.
+func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForCaches(ctx context.Context, wb []v1beta1.WorkspaceBinding, replacements map[string]string, namespace string) error {
+	now := time.Now()
+	var errs []error
+	for _, w := range wb {
+		if w.Cache == nil || !IsCacheKeyResolved(w, replacements) {
+			continue
+		}
+		claimName := GetCachePersistentVolumeClaimName(w, replacements)
+		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			claim := getCachePersistentVolumeClaim(w, replacements, namespace, now)
+			c.restoreCacheFromSnapshot(ctx, claim)
+			if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, claim, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				errs = append(errs, fmt.Errorf("failed to create cache PVC %s: %s", claimName, err))
+				continue
+			}
+			c.logger.Infof("Created cache PersistentVolumeClaim %s in namespace %s", claimName, namespace)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to retrieve cache PVC %s: %s", claimName, err))
+			continue
+		case pvc.DeletionTimestamp != nil:
+			// A terminating cache is a miss, but its name can only be used again once it is deleted.
+			errs = append(errs, fmt.Errorf("%w: %s", ErrCacheTerminating, claimName))
+			continue
+		default:
+			if pvc.Annotations == nil {
+				pvc.Annotations = map[string]string{}
+			}
+			pvc.Annotations[AnnotationCacheLastUsed] = now.UTC().Format(time.RFC3339)
+			if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Update(ctx, pvc, metav1.UpdateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update cache PVC %s: %s", claimName, err))
+				continue
+			}
+		}
+		if err := c.evictCaches(ctx, w, claimName, namespace, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// restoreCacheFromSnapshot sets the dataSource of a new cache PVC whose template doesn't set one to the most recent
+// VolumeSnapshot of a cache with the same name and key, if any. A snapshot that can't be looked up is a cache miss.
+func (c *defaultPVCHandler) restoreCacheFromSnapshot(ctx context.Context, claim *corev1.PersistentVolumeClaim) {
+	if claim.Spec.DataSource != nil {
+		return
+	}
+	name, err := c.snapshotHandler.FindVolumeSnapshot(ctx, CacheLabels(claim.Labels), claim.Namespace)
+	if err != nil {
+		c.logger.Warnf("Failed to look up a VolumeSnapshot to restore cache PersistentVolumeClaim %s from: %v", claim.Name, err)
+		return
+	}
+	if name == "" {
+		return
+	}
+	group := volumesnapshot.VolumeSnapshotResource.Group
+	claim.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &group,
+		Kind:     "VolumeSnapshot",
+		Name:     name,
+	}
+	c.logger.Infof("Restoring cache PersistentVolumeClaim %s from VolumeSnapshot %s", claim.Name, name)
+}
+
+// CacheLabels returns the labels identifying the cache of a PVC, or nil if it isn't a cache. The VolumeSnapshots
+// with these labels can restore the cache once its PVC is evicted.
+func CacheLabels(labels map[string]string) map[string]string {
+	if labels[LabelCache] == "" || labels[LabelCacheKey] == "" {
+		return nil
+	}
+	return map[string]string{
+		LabelCache:    labels[LabelCache],
+		LabelCacheKey: labels[LabelCacheKey],
+	}
+}
+
+// evictCaches deletes the caches with the same name as the cache of the workspace that have not been used
+// for longer than maxAge, and then the least recently used ones over maxEntries. The cache of the workspace
+// and the caches used by pods or runs that are still running are never deleted, and the caches already being
+// deleted are ignored.
+func (c *defaultPVCHandler) evictCaches(ctx context.Context, w v1beta1.WorkspaceBinding, claimName, namespace string, now time.Time) error {
+	if w.Cache.MaxAge == nil && w.Cache.MaxEntries == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{LabelCache: getCacheName(w)}).String()
+	list, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list cache PVCs %s: %s", getCacheName(w), err)
+	}
+	inUse, err := c.claimsInUse(namespace)
+	if err != nil {
+		return err
+	}
+
+	caches := list.Items
+	sort.Slice(caches, func(i, j int) bool {
+		return cacheLastUsed(caches[i]).After(cacheLastUsed(caches[j]))
+	})
+	var evicted []string
+	kept := 0
+	for _, pvc := range caches {
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if pvc.Name == claimName || inUse[pvc.Name] {
+			kept++
+			continue
+		}
+		expired := w.Cache.MaxAge != nil && now.Sub(cacheLastUsed(pvc)) > w.Cache.MaxAge.Duration
+		tooMany := w.Cache.MaxEntries > 0 && kept >= w.Cache.MaxEntries
+		if expired || tooMany {
+			evicted = append(evicted, pvc.Name)
+			continue
+		}
+		kept++
+	}
+
+	var errs []error
+	for _, name := range evicted {
+		if err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete cache PVC %s: %s", name, err))
+			continue
+		}
+		c.logger.Infof("Evicted cache PersistentVolumeClaim %s in namespace %s", name, namespace)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// claimsInUse returns the names of the PVCs that are mounted by the pods of the namespace that are not done,
+// or bound to the workspaces of the PipelineRuns and TaskRuns of the namespace that are not done, whose pods
+// may not have been created yet.
+func (c *defaultPVCHandler) claimsInUse(namespace string) (map[string]bool, error) {
+	inUse := map[string]bool{}
+	pods, err := c.podLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods using cache PVCs: %s", err)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil {
+				inUse[v.PersistentVolumeClaim.ClaimName] = true
+			}
+		}
+	}
+
+	pipelineRuns, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelineruns using cache PVCs: %s", err)
+	}
+	for _, pr := range pipelineRuns {
+		if !pr.IsDone() {
+			addWorkspaceClaims(inUse, pr.Spec.Workspaces, PipelineRunCacheKeyReplacements(pr))
+		}
+	}
+	taskRuns, err := c.taskRunLister.TaskRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list taskruns using cache PVCs: %s", err)
+	}
+	for _, tr := range taskRuns {
+		if !tr.IsDone() {
+			addWorkspaceClaims(inUse, tr.Spec.Workspaces, TaskRunCacheKeyReplacements(tr))
+		}
+	}
+	return inUse, nil
+}
+
+// addWorkspaceClaims adds the names of the PVCs and cache PVCs bound to the workspaces to claims. The caches
+// whose key can't be resolved yet are skipped.
+func addWorkspaceClaims(claims map[string]bool, wb []v1beta1.WorkspaceBinding, replacements map[string]string) {
+	for _, w := range wb {
+		switch {
+		case w.PersistentVolumeClaim != nil:
+			claims[w.PersistentVolumeClaim.ClaimName] = true
+		case w.Cache != nil && IsCacheKeyResolved(w, replacements):
+			claims[GetCachePersistentVolumeClaimName(w, replacements)] = true
+		}
+	}
+}
+
+// cacheLastUsed returns when the cache was last used, falling back to its creation time.
+func cacheLastUsed(pvc corev1.PersistentVolumeClaim) time.Time {
+	if t, err := time.Parse(time.RFC3339, pvc.Annotations[AnnotationCacheLastUsed]); err == nil {
+		return t
+	}
+	return pvc.CreationTimestamp.Time
+}
+
+func getCachePersistentVolumeClaim(w v1beta1.WorkspaceBinding, replacements map[string]string, namespace string, now time.Time) *corev1.PersistentVolumeClaim {
+	claim := w.Cache.VolumeClaimTemplate.DeepCopy()
+	claim.Name = GetCachePersistentVolumeClaimName(w, replacements)
+	claim.Namespace = namespace
+	if claim.Labels == nil {
+		claim.Labels = map[string]string{}
+	}
+	claim.Labels[LabelCache] = getCacheName(w)
+	claim.Labels[LabelCacheKey] = getCacheKeyHash(w, replacements)
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+	claim.Annotations[AnnotationCacheLastUsed] = now.UTC().Format(time.RFC3339)
+	return claim
+}
+
+// TaskRunCacheKeyReplacements returns the replacements the keys of the caches of the TaskRun are resolved with:
+// its params, and the defaults of the params of its Task it doesn't set, once its TaskSpec is resolved.
+func TaskRunCacheKeyReplacements(tr *v1beta1.TaskRun) map[string]string {
+	var specs []v1beta1.ParamSpec
+	if tr.Status.TaskSpec != nil {
+		specs = tr.Status.TaskSpec.Params
+	}
+	return paramReplacements(tr.Spec.Params, specs)
+}
+
+// PipelineRunCacheKeyReplacements returns the replacements the keys of the caches of the PipelineRun are resolved
+// with: its params, the defaults of the params of its Pipeline it doesn't set, once its PipelineSpec is resolved,
+// and the results of the TaskRuns recorded in its status.
+func PipelineRunCacheKeyReplacements(pr *v1beta1.PipelineRun) map[string]string {
+	var specs []v1beta1.ParamSpec
+	if pr.Status.PipelineSpec != nil {
+		specs = pr.Status.PipelineSpec.Params
+	}
+	replacements := paramReplacements(pr.Spec.Params, specs)
+	for _, trs := range pr.Status.TaskRuns {
+		if trs.Status != nil {
+			AddCacheKeyResults(replacements, trs.PipelineTaskName, trs.Status.TaskRunResults)
+		}
+	}
+	return replacements
+}
+
+// AddCacheKeyResults adds the results of the TaskRun of a PipelineTask to the replacements of cache keys.
+func AddCacheKeyResults(replacements map[string]string, pipelineTaskName string, results []v1beta1.TaskRunResult) {
+	for _, r := range results {
+		replacements[fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, pipelineTaskName, v1beta1.ResultResultPart, r.Name)] = r.Value
+	}
+}
+
+func paramReplacements(params []v1beta1.Param, specs []v1beta1.ParamSpec) map[string]string {
+	replacements := map[string]string{}
+	for _, spec := range specs {
+		if spec.Default != nil && spec.Default.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("params.%s", spec.Name)] = spec.Default.StringVal
+		}
+	}
+	for _, p := range params {
+		if p.Value.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
+		}
+	}
+	return replacements
+}
+
+// CacheKeyReferencesResults returns true if the key of the cache workspace references the results of
+// PipelineTasks, in which case its PVC is only known once they are done.
+func CacheKeyReferencesResults(w v1beta1.WorkspaceBinding) bool {
+	return w.Cache != nil && len(getCacheKeyResultRefs(w.Cache.Key)) > 0
+}
+
+// IsCacheKeyResolved returns true if the replacements hold all the results referenced by the key of the cache
+// workspace.
+func IsCacheKeyResolved(w v1beta1.WorkspaceBinding, replacements map[string]string) bool {
+	return len(getCacheKeyResultRefs(substitution.ApplyReplacements(w.Cache.Key, replacements))) == 0
+}
+
+func getCacheKeyResultRefs(key string) []*v1beta1.ResultRef {
+	expressions, _ := v1beta1.GetVarSubstitutionExpressionsForCacheKey(key)
+	return v1beta1.NewResultRefs(expressions)
+}
+
+// ValidateCacheKeys validates that the keys of the cache workspaces only reference the string params declared
+// by the Task or Pipeline, so that every key is resolved.
+func ValidateCacheKeys(wb []v1beta1.WorkspaceBinding, specs []v1beta1.ParamSpec) error {
+	names := sets.NewString()
+	for _, spec := range specs {
+		if spec.Type != v1beta1.ParamTypeArray {
+			names.Insert(spec.Name)
+		}
+	}
+	for _, w := range wb {
+		if w.Cache == nil {
+			continue
+		}
+		if err := substitution.ValidateVariableP(w.Cache.Key, "params", names); err != nil {
+			return fmt.Errorf("the key of cache workspace %q must only reference declared string params: %s", w.Name, err.Message)
+		}
+	}
+	return nil
+}
+
+// GetCachePersistentVolumeClaimName gets the name of the PersistentVolumeClaim of a cache workspace. The name
+// only depends on the name of the cache and its key resolved with the replacements, so that runs using the same
+// key get the same PersistentVolumeClaim.
+func GetCachePersistentVolumeClaimName(w v1beta1.WorkspaceBinding, replacements map[string]string) string {
+	return fmt.Sprintf("%s-%s", getCacheName(w), getCacheKeyHash(w, replacements))
+}
+
+// getCacheName returns the name of the template of the cache, or else the name of the workspace.
+func getCacheName(w v1beta1.WorkspaceBinding) string {
+	if w.Cache.VolumeClaimTemplate != nil && w.Cache.VolumeClaimTemplate.Name != "" {
+		return w.Cache.VolumeClaimTemplate.Name
+	}
+	return w.Name
+}
+
+func getCacheKeyHash(w v1beta1.WorkspaceBinding, replacements map[string]string) string {
+	key := substitution.ApplyReplacements(w.Cache.Key, replacements)
+	hashBytes := sha256.Sum256([]byte(key))
+	hashString := fmt.Sprintf("%x", hashBytes)
+	return hashString[:10]
+}
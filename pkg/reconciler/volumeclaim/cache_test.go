@@ -0,0 +1,505 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+)
+
+// newCachePVCHandler returns a PVC handler whose listers list the given pods and runs, and whose snapshot
+// handler finds the given VolumeSnapshots.
+func newCachePVCHandler(t *testing.T, clientset *fakek8s.Clientset, objs ...runtime.Object) *defaultPVCHandler {
+	t.Helper()
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	pods, pipelineRuns, taskRuns := newIndexer(), newIndexer(), newIndexer()
+	var snapshots []runtime.Object
+	for _, obj := range objs {
+		var err error
+		switch obj.(type) {
+		case *unstructured.Unstructured:
+			snapshots = append(snapshots, obj)
+		case *corev1.Pod:
+			err = pods.Add(obj)
+		case *v1beta1.PipelineRun:
+			err = pipelineRuns.Add(obj)
+		case *v1beta1.TaskRun:
+			err = taskRuns.Add(obj)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &defaultPVCHandler{
+		clientset:         clientset,
+		logger:            zap.NewExample().Sugar(),
+		podLister:         corev1listers.NewPodLister(pods),
+		pipelineRunLister: listers.NewPipelineRunLister(pipelineRuns),
+		taskRunLister:     listers.NewTaskRunLister(taskRuns),
+		snapshotHandler:   volumesnapshot.NewSnapshotHandler(fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), snapshots...), zap.NewExample().Sugar()),
+	}
+}
+
+func cacheWorkspace(maxAge *metav1.Duration, maxEntries int) v1beta1.WorkspaceBinding {
+	return v1beta1.WorkspaceBinding{
+		Name: "cache",
+		Cache: &v1beta1.WorkspaceCache{
+			Key: "go-$(params.go-sum-hash)",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "go-modules",
+				},
+			},
+			MaxAge:     maxAge,
+			MaxEntries: maxEntries,
+		},
+	}
+}
+
+func hashReplacements(hash string) map[string]string {
+	return map[string]string{"params.go-sum-hash": hash}
+}
+
+func existingCache(name string, lastUsed time.Time) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "ns",
+			Labels:      map[string]string{LabelCache: "go-modules"},
+			Annotations: map[string]string{AnnotationCacheLastUsed: lastUsed.UTC().Format(time.RFC3339)},
+		},
+	}
+}
+
+func podUsingClaim(name, claimName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "cache",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func claimWorkspace(claimName string) []v1beta1.WorkspaceBinding {
+	return []v1beta1.WorkspaceBinding{{
+		Name:                  "cache",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+	}}
+}
+
+func pipelineRunUsingClaim(name, claimName string, done bool) *v1beta1.PipelineRun {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       v1beta1.PipelineRunSpec{Workspaces: claimWorkspace(claimName)},
+	}
+	if done {
+		pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	}
+	return pr
+}
+
+func taskRunUsingClaim(name, claimName string, done bool) *v1beta1.TaskRun {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       v1beta1.TaskRunSpec{Workspaces: claimWorkspace(claimName)},
+	}
+	if done {
+		tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse})
+	}
+	return tr
+}
+
+func TestGetCachePersistentVolumeClaimName(t *testing.T) {
+	w := cacheWorkspace(nil, 0)
+	name := GetCachePersistentVolumeClaimName(w, hashReplacements("abc"))
+	if name != GetCachePersistentVolumeClaimName(w, hashReplacements("abc")) {
+		t.Errorf("expected the same name for the same key")
+	}
+	if name == GetCachePersistentVolumeClaimName(w, hashReplacements("def")) {
+		t.Errorf("expected different names for different keys")
+	}
+
+	w.Cache.VolumeClaimTemplate.Name = ""
+	if got := GetCachePersistentVolumeClaimName(w, hashReplacements("abc")); got[:len("cache-")] != "cache-" {
+		t.Errorf("expected the name of the workspace to be used when the template has no name, got %s", got)
+	}
+}
+
+// TestCacheKeyReplacementsWithDefaults tests that the keys of caches are resolved with the defaults of the params of
+// the resolved Task or Pipeline that the run doesn't set.
+func TestCacheKeyReplacementsWithDefaults(t *testing.T) {
+	w := cacheWorkspace(nil, 0)
+	specs := []v1beta1.ParamSpec{{
+		Name:    "go-sum-hash",
+		Type:    v1beta1.ParamTypeString,
+		Default: v1beta1.NewArrayOrString("abc"),
+	}}
+	want := GetCachePersistentVolumeClaimName(w, hashReplacements("abc"))
+
+	tr := &v1beta1.TaskRun{}
+	tr.Status.TaskSpec = &v1beta1.TaskSpec{Params: specs}
+	if got := GetCachePersistentVolumeClaimName(w, TaskRunCacheKeyReplacements(tr)); got != want {
+		t.Errorf("expected the default of the param to be used for the TaskRun, got %s instead of %s", got, want)
+	}
+	pr := &v1beta1.PipelineRun{}
+	pr.Status.PipelineSpec = &v1beta1.PipelineSpec{Params: specs}
+	if got := GetCachePersistentVolumeClaimName(w, PipelineRunCacheKeyReplacements(pr)); got != want {
+		t.Errorf("expected the default of the param to be used for the PipelineRun, got %s instead of %s", got, want)
+	}
+
+	tr.Spec.Params = []v1beta1.Param{{
+		Name:  "go-sum-hash",
+		Value: *v1beta1.NewArrayOrString("def"),
+	}}
+	if got := GetCachePersistentVolumeClaimName(w, TaskRunCacheKeyReplacements(tr)); got != GetCachePersistentVolumeClaimName(w, hashReplacements("def")) {
+		t.Errorf("expected the param of the TaskRun to override the default, got %s", got)
+	}
+}
+
+func TestValidateCacheKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		key     string
+		specs   []v1beta1.ParamSpec
+		wantErr bool
+	}{{
+		name:  "declared param",
+		key:   "go-$(params.go-sum-hash)",
+		specs: []v1beta1.ParamSpec{{Name: "go-sum-hash", Type: v1beta1.ParamTypeString}},
+	}, {
+		name: "no param",
+		key:  "go",
+	}, {
+		name:    "undeclared param",
+		key:     "go-$(params.go-sum)",
+		specs:   []v1beta1.ParamSpec{{Name: "go-sum-hash", Type: v1beta1.ParamTypeString}},
+		wantErr: true,
+	}, {
+		name:    "array param",
+		key:     "go-$(params.go-sum-hash)",
+		specs:   []v1beta1.ParamSpec{{Name: "go-sum-hash", Type: v1beta1.ParamTypeArray}},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := cacheWorkspace(nil, 0)
+			w.Cache.Key = tc.key
+			err := ValidateCacheKeys([]v1beta1.WorkspaceBinding{{Name: "source"}, w}, tc.specs)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateCacheKeys() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestCacheKeyReferencingResults tests that the key of a cache of a PipelineRun is resolved with the results
+// of the TaskRuns in its status, and that a cache whose results are not produced yet isn't created.
+func TestCacheKeyReferencingResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := cacheWorkspace(nil, 0)
+	w.Cache.Key = "go-$(tasks.hash.results.go-sum)"
+	if !CacheKeyReferencesResults(w) {
+		t.Fatalf("expected the key %q to reference results", w.Cache.Key)
+	}
+	if CacheKeyReferencesResults(cacheWorkspace(nil, 0)) {
+		t.Errorf("expected a key only referencing params not to reference results")
+	}
+
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := newCachePVCHandler(t, fakekubeclient)
+	pr := &v1beta1.PipelineRun{}
+	if IsCacheKeyResolved(w, PipelineRunCacheKeyReplacements(pr)) {
+		t.Errorf("expected the key not to be resolved before the results are produced")
+	}
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, PipelineRunCacheKeyReplacements(pr), "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{}); err != nil || len(list.Items) != 0 {
+		t.Errorf("expected no cache PVC before the results are produced, got %v, %v", list, err)
+	}
+
+	pr.Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pr-hash": {
+			PipelineTaskName: "hash",
+			Status: &v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "go-sum", Value: "abc"}},
+			}},
+		},
+	}
+	replacements := PipelineRunCacheKeyReplacements(pr)
+	if !IsCacheKeyResolved(w, replacements) {
+		t.Fatalf("expected the key to be resolved with the results, got replacements %v", replacements)
+	}
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, replacements, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := cacheWorkspace(nil, 0)
+	want.Cache.Key = "go-abc"
+	claimName := GetCachePersistentVolumeClaimName(w, replacements)
+	if claimName != GetCachePersistentVolumeClaimName(want, nil) {
+		t.Errorf("expected the key to be resolved with the result value, got %s", claimName)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the cache PVC %s to be created once the results are produced, got %v", claimName, err)
+	}
+}
+
+// TestCreatePersistentVolumeClaimsForCachesFromSnapshot tests that a cache PVC that doesn't exist is restored from
+// the most recent VolumeSnapshot ready to use with the labels of the cache.
+func TestCreatePersistentVolumeClaimsForCachesFromSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := cacheWorkspace(nil, 0)
+	replacements := hashReplacements("abc")
+	claimName := GetCachePersistentVolumeClaimName(w, replacements)
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"readyToUse": true},
+	}}
+	snapshot.SetGroupVersionKind(volumesnapshot.VolumeSnapshotResource.GroupVersion().WithKind("VolumeSnapshot"))
+	snapshot.SetName("build-cache")
+	snapshot.SetNamespace("ns")
+	snapshot.SetLabels(map[string]string{
+		LabelCache:    "go-modules",
+		LabelCacheKey: getCacheKeyHash(w, replacements),
+	})
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := newCachePVCHandler(t, fakekubeclient, snapshot)
+
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, replacements, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	group := "snapshot.storage.k8s.io"
+	want := &corev1.TypedLocalObjectReference{APIGroup: &group, Kind: "VolumeSnapshot", Name: "build-cache"}
+	if d := cmp.Diff(want, pvc.Spec.DataSource); d != "" {
+		t.Errorf("expected the cache PVC to be restored from the VolumeSnapshot %s", diff.PrintWantGot(d))
+	}
+
+	// a cache with another key starts empty
+	other := hashReplacements("def")
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, other, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvc, err = fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, GetCachePersistentVolumeClaimName(w, other), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pvc.Spec.DataSource != nil {
+		t.Errorf("expected a cache PVC without snapshot to start empty, got dataSource %v", pvc.Spec.DataSource)
+	}
+}
+
+// TestCreatePersistentVolumeClaimsForCaches tests that a cache PVC that is not owned by the run is created
+// the first time a key is used, and that it is marked as used when it already exists.
+func TestCreatePersistentVolumeClaimsForCaches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := cacheWorkspace(nil, 0)
+	replacements := hashReplacements("abc")
+	claimName := GetCachePersistentVolumeClaimName(w, replacements)
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := newCachePVCHandler(t, fakekubeclient)
+
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, replacements, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pvc.OwnerReferences) != 0 {
+		t.Errorf("expected the cache PVC not to be owned, got %v", pvc.OwnerReferences)
+	}
+	if pvc.Labels[LabelCache] != "go-modules" {
+		t.Errorf("expected the cache PVC to be labelled with the cache name, got %v", pvc.Labels)
+	}
+	if pvc.Annotations[AnnotationCacheLastUsed] == "" {
+		t.Errorf("expected the cache PVC to be annotated with its last use, got %v", pvc.Annotations)
+	}
+
+	// mark the cache as used a while ago, and use it again
+	pvc.Annotations[AnnotationCacheLastUsed] = "2020-01-01T00:00:00Z"
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Update(ctx, pvc, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, replacements, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvc, err = fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pvc.Annotations[AnnotationCacheLastUsed] == "2020-01-01T00:00:00Z" {
+		t.Errorf("expected the last use of the cache PVC to be updated")
+	}
+}
+
+// TestCreatePersistentVolumeClaimsForCachesEviction tests that the caches with the same name are evicted
+// according to maxAge and maxEntries, and that the cache in use is kept.
+func TestCreatePersistentVolumeClaimsForCachesEviction(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name       string
+		maxAge     *metav1.Duration
+		maxEntries int
+		running    bool
+		runs       bool
+		terminated bool
+		want       []string
+	}{{
+		name: "no eviction",
+		want: []string{"go-modules-new", "go-modules-old", "go-modules-older", "other"},
+	}, {
+		name:   "by age",
+		maxAge: &metav1.Duration{Duration: 36 * time.Hour},
+		want:   []string{"go-modules-new", "other"},
+	}, {
+		name:       "by count",
+		maxEntries: 2,
+		want:       []string{"go-modules-new", "other"},
+	}, {
+		name:       "by count keeps the most recently used",
+		maxEntries: 3,
+		want:       []string{"go-modules-new", "go-modules-old", "other"},
+	}, {
+		name:    "by age keeps the caches used by running pods",
+		maxAge:  &metav1.Duration{Duration: 36 * time.Hour},
+		running: true,
+		want:    []string{"go-modules-new", "go-modules-older", "other"},
+	}, {
+		name:   "by age keeps the caches used by runs that are not done",
+		maxAge: &metav1.Duration{Duration: 36 * time.Hour},
+		runs:   true,
+		want:   []string{"go-modules-new", "go-modules-older", "other"},
+	}, {
+		name:       "by count ignores the caches being deleted",
+		maxEntries: 3,
+		terminated: true,
+		want:       []string{"go-modules-new", "go-modules-old", "go-modules-older", "other"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			w := cacheWorkspace(tc.maxAge, tc.maxEntries)
+			replacements := hashReplacements("abc")
+			claimName := GetCachePersistentVolumeClaimName(w, replacements)
+			other := existingCache("other", now.Add(-72*time.Hour))
+			other.Labels[LabelCache] = "npm"
+			older := existingCache("go-modules-older", now.Add(-72*time.Hour))
+			if tc.terminated {
+				older.DeletionTimestamp = &metav1.Time{Time: now}
+			}
+			objs := []runtime.Object{
+				existingCache("go-modules-new", now.Add(-time.Hour)),
+				existingCache("go-modules-old", now.Add(-48*time.Hour)),
+				older,
+				other,
+			}
+			var used []runtime.Object
+			if tc.running {
+				used = append(used, podUsingClaim("running", "go-modules-older", corev1.PodRunning), podUsingClaim("done", "go-modules-old", corev1.PodSucceeded))
+			}
+			if tc.runs {
+				// the pods of the runs may not have been created yet
+				used = append(used, pipelineRunUsingClaim("running", "go-modules-older", false), taskRunUsingClaim("done", "go-modules-old", true))
+			}
+			fakekubeclient := fakek8s.NewSimpleClientset(objs...)
+			pvcHandler := newCachePVCHandler(t, fakekubeclient, used...)
+
+			if err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w}, replacements, "ns"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			list, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, pvc := range list.Items {
+				if pvc.Name != claimName {
+					got = append(got, pvc.Name)
+				}
+			}
+			sort.Strings(got)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected remaining caches %s", diff.PrintWantGot(d))
+			}
+			if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the cache in use to be kept, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestCreatePersistentVolumeClaimsForCachesTerminating tests that a cache PVC being deleted is not reused,
+// and doesn't prevent the caches of the other workspaces from being created.
+func TestCreatePersistentVolumeClaimsForCachesTerminating(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := cacheWorkspace(nil, 0)
+	other := cacheWorkspace(nil, 0)
+	other.Name = "other"
+	other.Cache.VolumeClaimTemplate.Name = "npm-modules"
+	replacements := hashReplacements("abc")
+	claim := existingCache(GetCachePersistentVolumeClaimName(w, replacements), time.Now())
+	claim.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	fakekubeclient := fakek8s.NewSimpleClientset(claim)
+	pvcHandler := newCachePVCHandler(t, fakekubeclient)
+
+	err := pvcHandler.CreatePersistentVolumeClaimsForCaches(ctx, []v1beta1.WorkspaceBinding{w, other}, replacements, "ns")
+	if !IsCacheTerminating(err) {
+		t.Errorf("expected an error for the terminating cache, got: %v", err)
+	}
+	otherClaimName := GetCachePersistentVolumeClaimName(other, replacements)
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, otherClaimName, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the cache of the other workspace to be created, got: %v", err)
+	}
+}
@@ -21,32 +21,55 @@ import (
 	"crypto/sha256"
 	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumesnapshot"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 const (
 	// ReasonCouldntCreateWorkspacePVC indicates that a Pipeline expects a workspace from a
 	// volumeClaimTemplate but couldn't create a claim.
 	ReasonCouldntCreateWorkspacePVC = "CouldntCreateWorkspacePVC"
+
+	// ReasonDeletedWorkspacePVC indicates that a claim created from a volumeClaimTemplate was
+	// deleted according to its retention policy.
+	ReasonDeletedWorkspacePVC = "DeletedWorkspacePVC"
 )
 
 type PvcHandler interface {
 	CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string, succeeded bool) ([]string, error)
+	CreatePersistentVolumeClaimsForCaches(ctx context.Context, wb []v1beta1.WorkspaceBinding, replacements map[string]string, namespace string) error
 }
 
 type defaultPVCHandler struct {
 	clientset clientset.Interface
 	logger    *zap.SugaredLogger
+	// The listers find the pods and runs using cache PVCs, which are never evicted while in use.
+	podLister         corev1listers.PodLister
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	// The snapshot handler finds the VolumeSnapshots the caches that don't exist are restored from.
+	snapshotHandler volumesnapshot.SnapshotHandler
 }
 
-func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) PvcHandler {
-	return &defaultPVCHandler{clientset, logger}
+func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger, podLister corev1listers.PodLister, pipelineRunLister listers.PipelineRunLister, taskRunLister listers.TaskRunLister, snapshotHandler volumesnapshot.SnapshotHandler) PvcHandler {
+	return &defaultPVCHandler{
+		clientset:         clientset,
+		logger:            logger,
+		podLister:         podLister,
+		pipelineRunLister: pipelineRunLister,
+		taskRunLister:     taskRunLister,
+		snapshotHandler:   snapshotHandler,
+	}
 }
 
 // CreatePersistentVolumeClaimsForWorkspaces checks if a PVC named <claim-name>-<workspace-name>-<owner-name> exists;
@@ -73,6 +96,56 @@ func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx contex
 	return errorutils.NewAggregate(errs)
 }
 
+// DeletePersistentVolumeClaimsForWorkspaces deletes the PVCs created from volumeClaimTemplate workspaces once
+// their owner is done, when the retention policy of the workspace, or else the default-volume-claim-retention-policy,
+// asks for it. deleteOnCompletion deletes them in any case, and deleteOnSuccess only if the owner succeeded.
+// The names of the PVCs that were deleted are returned; PVCs that no longer exist are ignored.
+func (c *defaultPVCHandler) DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string, succeeded bool) ([]string, error) {
+	var deleted []string
+	var errs []error
+	for _, w := range wb {
+		if w.VolumeClaimTemplate == nil {
+			continue
+		}
+		switch getRetentionPolicy(ctx, w) {
+		case v1beta1.VolumeClaimDeleteOnCompletion:
+		case v1beta1.VolumeClaimDeleteOnSuccess:
+			if !succeeded {
+				continue
+			}
+		default:
+			continue
+		}
+
+		claimName := GetPersistentVolumeClaimName(w.VolumeClaimTemplate, w, ownerReference)
+		err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, claimName, metav1.DeleteOptions{})
+		switch {
+		case err == nil:
+			c.logger.Infof("Deleted PersistentVolumeClaim %s in namespace %s", claimName, namespace)
+			deleted = append(deleted, claimName)
+		case !apierrors.IsNotFound(err):
+			errs = append(errs, fmt.Errorf("failed to delete PVC %s: %s", claimName, err))
+		}
+	}
+	return deleted, errorutils.NewAggregate(errs)
+}
+
+// getRetentionPolicy returns the retention policy of the workspace, falling back to the
+// default-volume-claim-retention-policy and then to retaining the claim. The claims of snapshotted
+// workspaces are always retained, so that they outlive the snapshots taken when the run is done.
+func getRetentionPolicy(ctx context.Context, w v1beta1.WorkspaceBinding) v1beta1.VolumeClaimRetentionPolicy {
+	if w.VolumeClaimRetentionPolicy != "" {
+		return w.VolumeClaimRetentionPolicy
+	}
+	if w.Snapshot != nil {
+		return v1beta1.VolumeClaimRetain
+	}
+	if policy := config.FromContextOrDefaults(ctx).Defaults.DefaultVolumeClaimRetention; policy != "" {
+		return v1beta1.VolumeClaimRetentionPolicy(policy)
+	}
+	return v1beta1.VolumeClaimRetain
+}
+
 func getPersistentVolumeClaims(workspaceBindings []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) map[string]*corev1.PersistentVolumeClaim {
 	claims := make(map[string]*corev1.PersistentVolumeClaim)
 	for _, workspaceBinding := range workspaceBindings {
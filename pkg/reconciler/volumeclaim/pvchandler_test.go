@@ -21,9 +21,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 )
@@ -72,7 +76,7 @@ func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
 	ownerRef := metav1.OwnerReference{Name: ownerName}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{clientset: fakekubeclient, logger: zap.NewExample().Sugar()}
 
 	// when
 
@@ -137,7 +141,7 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 	ownerRef := metav1.OwnerReference{Name: ownerName}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := defaultPVCHandler{clientset: fakekubeclient, logger: zap.NewExample().Sugar()}
 
 	// when
 
@@ -158,3 +162,93 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 		t.Fatalf("unexpected PVC name on created PVC; exptected: %s got: %s", expectedPVCName, pvc.Name)
 	}
 }
+
+// TestDeletePersistentVolumeClaimsForWorkspaces tests that the PVCs created from volumeClaimTemplate workspaces
+// are deleted according to the retention policy of the workspace or the default retention policy.
+func TestDeletePersistentVolumeClaimsForWorkspaces(t *testing.T) {
+	workspace := func(name string, policy v1beta1.VolumeClaimRetentionPolicy) v1beta1.WorkspaceBinding {
+		return v1beta1.WorkspaceBinding{
+			Name: name,
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pvc",
+				},
+			},
+			VolumeClaimRetentionPolicy: policy,
+		}
+	}
+	workspaces := []v1beta1.WorkspaceBinding{
+		workspace("retain", v1beta1.VolumeClaimRetain),
+		workspace("on-completion", v1beta1.VolumeClaimDeleteOnCompletion),
+		workspace("on-success", v1beta1.VolumeClaimDeleteOnSuccess),
+		workspace("default", ""),
+		workspace("snapshot", ""),
+	}
+	workspaces[4].Snapshot = &v1beta1.WorkspaceSnapshot{}
+	ownerRef := metav1.OwnerReference{Name: "pipelinerun1"}
+	claimName := func(i int) string {
+		return GetPersistentVolumeClaimName(workspaces[i].VolumeClaimTemplate, workspaces[i], ownerRef)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		defaultPolicy string
+		succeeded     bool
+		want          []string
+	}{{
+		name:      "failed owner",
+		succeeded: false,
+		want:      []string{claimName(1)},
+	}, {
+		name:      "succeeded owner",
+		succeeded: true,
+		want:      []string{claimName(1), claimName(2)},
+	}, {
+		name:          "default policy applies to workspaces without a policy or a snapshot",
+		defaultPolicy: "deleteOnCompletion",
+		succeeded:     false,
+		want:          []string{claimName(1), claimName(3)},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx = config.ToContext(ctx, &config.Config{
+				Defaults: &config.Defaults{DefaultVolumeClaimRetention: tc.defaultPolicy},
+			})
+
+			namespace := "ns"
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			pvcHandler := defaultPVCHandler{clientset: fakekubeclient, logger: zap.NewExample().Sugar()}
+			if err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			deleted, err := pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace, tc.succeeded)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, deleted); d != "" {
+				t.Errorf("unexpected deleted PVCs %s", diff.PrintWantGot(d))
+			}
+
+			for i := range workspaces {
+				_, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName(i), metav1.GetOptions{})
+				wasDeleted := false
+				for _, name := range tc.want {
+					if name == claimName(i) {
+						wasDeleted = true
+					}
+				}
+				if wasDeleted != apierrors.IsNotFound(err) {
+					t.Errorf("unexpected state for PVC %s; expected deleted: %t, got error: %v", claimName(i), wasDeleted, err)
+				}
+			}
+
+			// Deleting again is a no-op
+			deleted, err = pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace, tc.succeeded)
+			if err != nil || len(deleted) != 0 {
+				t.Errorf("expected no PVCs to be deleted again, got %v, %v", deleted, err)
+			}
+		})
+	}
+}
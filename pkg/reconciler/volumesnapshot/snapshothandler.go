@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// ReasonCreatedVolumeSnapshot indicates that a VolumeSnapshot was taken of the
+	// PersistentVolumeClaim of a workspace.
+	ReasonCreatedVolumeSnapshot = "CreatedVolumeSnapshot"
+
+	// ReasonCouldntCreateVolumeSnapshot indicates that a VolumeSnapshot could not be taken
+	// of the PersistentVolumeClaim of a workspace.
+	ReasonCouldntCreateVolumeSnapshot = "CouldntCreateVolumeSnapshot"
+)
+
+// VolumeSnapshotResource is the resource of the CSI VolumeSnapshots, in the preferred version.
+var VolumeSnapshotResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1",
+	Resource: "volumesnapshots",
+}
+
+// volumeSnapshotVersions are the versions the VolumeSnapshots are created with, most preferred first:
+// the CRDs of external-snapshotter 4.0 and later serve v1, and older ones only v1beta1.
+var volumeSnapshotVersions = []string{VolumeSnapshotResource.Version, "v1beta1"}
+
+type SnapshotHandler interface {
+	CreateVolumeSnapshot(ctx context.Context, name, claimName, className string, labels map[string]string, ownerReference metav1.OwnerReference, namespace string) error
+	FindVolumeSnapshot(ctx context.Context, labels map[string]string, namespace string) (string, error)
+}
+
+type defaultSnapshotHandler struct {
+	client dynamic.Interface
+	logger *zap.SugaredLogger
+}
+
+func NewSnapshotHandler(client dynamic.Interface, logger *zap.SugaredLogger) SnapshotHandler {
+	return &defaultSnapshotHandler{client, logger}
+}
+
+// CreateVolumeSnapshot creates a VolumeSnapshot named name of the PersistentVolumeClaim claimName, with the labels
+// and owned by the provided OwnerReference. The default VolumeSnapshotClass of the CSI driver is used when className
+// is empty.
+// A VolumeSnapshot that already exists is left as it is. The VolumeSnapshot is created with the first version
+// of volumeSnapshotVersions the cluster serves.
+func (c *defaultSnapshotHandler) CreateVolumeSnapshot(ctx context.Context, name, claimName, className string, labels map[string]string, ownerReference metav1.OwnerReference, namespace string) error {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": claimName,
+		},
+	}
+	if className != "" {
+		spec["volumeSnapshotClassName"] = className
+	}
+
+	var err error
+	for _, version := range volumeSnapshotVersions {
+		resource := VolumeSnapshotResource
+		resource.Version = version
+		snapshot := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		snapshot.SetGroupVersionKind(resource.GroupVersion().WithKind("VolumeSnapshot"))
+		snapshot.SetName(name)
+		snapshot.SetNamespace(namespace)
+		if len(labels) > 0 {
+			snapshot.SetLabels(labels)
+		}
+		snapshot.SetOwnerReferences([]metav1.OwnerReference{ownerReference})
+
+		_, err = c.client.Resource(resource).Namespace(namespace).Create(ctx, snapshot, metav1.CreateOptions{})
+		if !apierrors.IsNotFound(err) {
+			break
+		}
+	}
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to create VolumeSnapshot %s of PVC %s: %w", name, claimName, err)
+	}
+	c.logger.Infof("Created VolumeSnapshot %s of PersistentVolumeClaim %s in namespace %s", name, claimName, namespace)
+	return nil
+}
+
+// FindVolumeSnapshot returns the name of the most recent VolumeSnapshot of the namespace with the labels that is
+// ready to be used as the dataSource of a PersistentVolumeClaim, or an empty name if there is none or the cluster
+// doesn't serve VolumeSnapshots.
+func (c *defaultSnapshotHandler) FindVolumeSnapshot(ctx context.Context, labels map[string]string, namespace string) (string, error) {
+	selector := metav1.FormatLabelSelector(metav1.SetAsLabelSelector(labels))
+	for _, version := range volumeSnapshotVersions {
+		resource := VolumeSnapshotResource
+		resource.Version = version
+		list, err := c.client.Resource(resource).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return "", fmt.Errorf("failed to list VolumeSnapshots %s: %w", selector, err)
+		}
+		var name string
+		var created time.Time
+		for _, snapshot := range list.Items {
+			if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); !ready || snapshot.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if name == "" || snapshot.GetCreationTimestamp().After(created) {
+				name, created = snapshot.GetName(), snapshot.GetCreationTimestamp().Time
+			}
+		}
+		return name, nil
+	}
+	return "", nil
+}
+
+// IsPermanent returns true if the VolumeSnapshot couldn't be created because the cluster doesn't serve
+// VolumeSnapshots, or because the controller isn't allowed to create them, which retrying won't fix.
+func IsPermanent(err error) bool {
+	var statusErr *apierrors.StatusError
+	return errors.As(err, &statusErr) && (apierrors.IsNotFound(statusErr) || apierrors.IsForbidden(statusErr))
+}
+
+// GetVolumeSnapshotName gets the name of the VolumeSnapshot of a workspace taken when the TaskRun owning it
+// completed. The name is <owner-name>-<workspace-name>, so that a single snapshot is taken per workspace.
+func GetVolumeSnapshotName(wb v1beta1.WorkspaceBinding, owner metav1.OwnerReference) string {
+	return kmeta.ChildName(owner.Name, "-"+wb.Name)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+// check that defaultSnapshotHandler implements SnapshotHandler
+var _ SnapshotHandler = (*defaultSnapshotHandler)(nil)
+
+// TestCreateVolumeSnapshot tests that a VolumeSnapshot of the PVC is created with the expected
+// name, class, labels and OwnerReference, and that creating it again is not an error.
+func TestCreateVolumeSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ownerRef := metav1.OwnerReference{UID: "0123", Name: "build"}
+	wb := v1beta1.WorkspaceBinding{Name: "source"}
+	name := GetVolumeSnapshotName(wb, ownerRef)
+	if name != "build-source" {
+		t.Errorf("unexpected VolumeSnapshot name %s", name)
+	}
+
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	snapshotHandler := defaultSnapshotHandler{client, zap.NewExample().Sugar()}
+	labels := map[string]string{"tekton.dev/cache": "go-modules"}
+	for i := 0; i < 2; i++ {
+		if err := snapshotHandler.CreateVolumeSnapshot(ctx, name, "source-pvc", "csi-snapclass", labels, ownerRef, "ns"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshot, err := client.Resource(VolumeSnapshotResource).Namespace("ns").Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]metav1.OwnerReference{ownerRef}, snapshot.GetOwnerReferences()); d != "" {
+		t.Errorf("unexpected owner references %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(labels, snapshot.GetLabels()); d != "" {
+		t.Errorf("unexpected labels %s", diff.PrintWantGot(d))
+	}
+	if claimName, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName"); claimName != "source-pvc" {
+		t.Errorf("expected the snapshot of source-pvc, got %s", claimName)
+	}
+	if className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName"); className != "csi-snapclass" {
+		t.Errorf("expected the class csi-snapclass, got %s", className)
+	}
+}
+
+// TestCreateVolumeSnapshotV1beta1 tests that the VolumeSnapshot is created with the v1beta1 version when the
+// cluster doesn't serve the v1 one, and that failing to create it in any version is a permanent error.
+func TestCreateVolumeSnapshotV1beta1(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v1beta1Resource := VolumeSnapshotResource
+	v1beta1Resource.Version = "v1beta1"
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	served := map[string]bool{"v1beta1": true}
+	client.PrependReactor("create", "volumesnapshots", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if !served[action.GetResource().Version] {
+			return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+	snapshotHandler := defaultSnapshotHandler{client, zap.NewExample().Sugar()}
+	ownerRef := metav1.OwnerReference{UID: "0123", Name: "build"}
+	if err := snapshotHandler.CreateVolumeSnapshot(ctx, "build-source", "source-pvc", "", nil, ownerRef, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Resource(v1beta1Resource).Namespace("ns").Get(ctx, "build-source", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the v1beta1 VolumeSnapshot to be created: %v", err)
+	}
+
+	served = map[string]bool{}
+	err := snapshotHandler.CreateVolumeSnapshot(ctx, "build-cache", "cache-pvc", "", nil, ownerRef, "ns")
+	if err == nil || !IsPermanent(err) {
+		t.Errorf("expected a permanent error, got %v", err)
+	}
+}
+
+func volumeSnapshot(name string, labels map[string]string, created time.Time, ready bool) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"readyToUse": ready},
+	}}
+	snapshot.SetGroupVersionKind(VolumeSnapshotResource.GroupVersion().WithKind("VolumeSnapshot"))
+	snapshot.SetName(name)
+	snapshot.SetNamespace("ns")
+	snapshot.SetLabels(labels)
+	snapshot.SetCreationTimestamp(metav1.NewTime(created))
+	return snapshot
+}
+
+// TestFindVolumeSnapshot tests that the most recent VolumeSnapshot with the labels that is ready to use is
+// found, and that no VolumeSnapshot is found when the cluster doesn't serve them.
+func TestFindVolumeSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	labels := map[string]string{"tekton.dev/cache": "go-modules", "tekton.dev/cache-key": "abc"}
+	now := time.Now()
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		volumeSnapshot("old", labels, now.Add(-2*time.Hour), true),
+		volumeSnapshot("recent", labels, now.Add(-time.Hour), true),
+		volumeSnapshot("not-ready", labels, now, false),
+		volumeSnapshot("other-key", map[string]string{"tekton.dev/cache": "go-modules", "tekton.dev/cache-key": "def"}, now, true),
+	)
+	snapshotHandler := defaultSnapshotHandler{client, zap.NewExample().Sugar()}
+	name, err := snapshotHandler.FindVolumeSnapshot(ctx, labels, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "recent" {
+		t.Errorf("expected the most recent VolumeSnapshot ready to use, got %q", name)
+	}
+
+	client.PrependReactor("list", "volumesnapshots", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), "")
+	})
+	name, err = snapshotHandler.FindVolumeSnapshot(ctx, labels, "ns")
+	if err != nil || name != "" {
+		t.Errorf("expected no VolumeSnapshot when the cluster doesn't serve them, got %q, %v", name, err)
+	}
+}
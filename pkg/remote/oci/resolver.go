@@ -18,6 +18,7 @@ package oci
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -38,6 +39,10 @@ const (
 	APIVersionAnnotation = "dev.tekton.image.apiVersion"
 	TitleAnnotation      = "dev.tekton.image.name"
 	MaximumBundleObjects = 10
+
+	// FileKind is the kind of the layers holding a file, such as a Step's
+	// script, rather than a Tekton resource.
+	FileKind = "file"
 )
 
 // Resolver implements the Resolver interface using OCI images.
@@ -120,6 +125,37 @@ func (o *Resolver) Get(kind, name string) (runtime.Object, error) {
 	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
 }
 
+// GetFile returns the contents of the file with the given name, which is stored in a layer of kind FileKind.
+func (o *Resolver) GetFile(name string) ([]byte, error) {
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	img, err := o.retrieveImage(timeoutCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse image manifest: %w", err)
+	}
+
+	if err := o.checkImageCompliance(manifest); err != nil {
+		return nil, err
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("could not read image layers: %w", err)
+	}
+
+	for idx, l := range manifest.Layers {
+		if l.Annotations[KindAnnotation] == FileKind && l.Annotations[TitleAnnotation] == name {
+			return readFileLayer(layers[idx])
+		}
+	}
+	return nil, fmt.Errorf("could not find file in image with name: %s", name)
+}
+
 // retrieveImage will fetch the image's contents and manifest.
 func (o *Resolver) retrieveImage(ctx context.Context) (v1.Image, error) {
 	imgRef, err := imgname.ParseReference(o.imageReference)
@@ -179,3 +215,29 @@ func readRawLayer(layer v1.Layer) (runtime.Object, error) {
 	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(contents, nil, nil)
 	return obj, err
 }
+
+// Utility function to read out the contents of an image layer holding a file, either as the only file of a tarball or
+// as raw bytes.
+func readFileLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image layer: %w", err)
+	}
+	defer rc.Close()
+
+	contents, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("could not read contents of image layer: %w", err)
+	}
+
+	treader := tar.NewReader(bytes.NewReader(contents))
+	if _, err := treader.Next(); err != nil {
+		// Not a tarball, so the layer holds the file's contents as they are.
+		return contents, nil
+	}
+	file, err := ioutil.ReadAll(treader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tar bundle: %w", err)
+	}
+	return file, nil
+}
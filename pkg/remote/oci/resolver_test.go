@@ -139,3 +139,38 @@ func TestOCIResolver(t *testing.T) {
 func getObjectName(obj runtime.Object) string {
 	return reflect.Indirect(reflect.ValueOf(obj)).FieldByName("ObjectMeta").FieldByName("Name").String()
 }
+
+func TestOCIResolverGetFile(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"build.sh":        "#!/bin/sh\nmake build\n",
+		"scripts/test.py": "print('hello')\n",
+	}
+	ref, err := test.CreateImageWithFiles(fmt.Sprintf("%s/testociresolve/files", u.Host), files, tb.Task("simple-task", tb.TaskType))
+	if err != nil {
+		t.Fatalf("could not push image: %#v", err)
+	}
+
+	resolver := oci.NewResolver(ref, authn.DefaultKeychain).(*oci.Resolver)
+	for name, want := range files {
+		got, err := resolver.GetFile(name)
+		if err != nil {
+			t.Fatalf("could not retrieve file %q from image: %v", name, err)
+		}
+		if d := cmp.Diff(want, string(got)); d != "" {
+			t.Errorf("file %q %s", name, diff.PrintWantGot(d))
+		}
+	}
+
+	// A Task is not a file, even if it has the same name.
+	if _, err := resolver.GetFile("simple-task"); err == nil {
+		t.Error("expected an error getting a file that isn't in the image")
+	}
+}
@@ -67,6 +67,15 @@ func CreateVolumes(wb []v1beta1.WorkspaceBinding) map[string]corev1.Volume {
 		case w.Secret != nil:
 			s := *w.Secret
 			v.setVolumeSource(w.Name, name, corev1.VolumeSource{Secret: &s})
+		case w.Projected != nil:
+			p := *w.Projected
+			v.setVolumeSource(w.Name, name, corev1.VolumeSource{Projected: &p})
+		case w.CSI != nil:
+			csi := *w.CSI
+			v.setVolumeSource(w.Name, name, corev1.VolumeSource{CSI: &csi})
+		case w.OCI != nil:
+			// The content of an OCI workspace is pulled into, and pushed from, an emptyDir by the steps
+			v.setVolumeSource(w.Name, name, corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}})
 		}
 	}
 	return v
@@ -84,7 +93,10 @@ func getDeclaredWorkspace(name string, w []v1beta1.WorkspaceDeclaration) (*v1bet
 
 // Apply will update the StepTemplate and Volumes declaration in ts so that the workspaces
 // specified through wb combined with the declared workspaces in ts will be available for
-// all containers in the resulting pod.
+// all containers in the resulting pod. If any Step or Sidecar in ts declares the
+// workspaces it uses, the workspaces are instead mounted directly into the Steps and
+// Sidecars: Steps that declare workspaces and Sidecars only get the workspaces they
+// declare, while Steps that don't declare any get all of them.
 func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]corev1.Volume) (*v1beta1.TaskSpec, error) {
 	// If there are no bound workspaces, we don't need to do anything
 	if len(wb) == 0 {
@@ -92,11 +104,8 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 	}
 
 	addedVolumes := sets.NewString()
-
-	// Initialize StepTemplate if it hasn't been already
-	if ts.StepTemplate == nil {
-		ts.StepTemplate = &corev1.Container{}
-	}
+	mounts := map[string]corev1.VolumeMount{}
+	var allMounts []corev1.VolumeMount
 
 	for i := range wb {
 		w, err := getDeclaredWorkspace(wb[i].Name, ts.Workspaces)
@@ -106,12 +115,14 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 		// Get the volume we should be using for this binding
 		vv := v[wb[i].Name]
 
-		ts.StepTemplate.VolumeMounts = append(ts.StepTemplate.VolumeMounts, corev1.VolumeMount{
+		vm := corev1.VolumeMount{
 			Name:      vv.Name,
 			MountPath: w.GetMountPath(),
 			SubPath:   wb[i].SubPath,
-			ReadOnly:  w.ReadOnly,
-		})
+			ReadOnly:  w.ReadOnly || wb[i].ReadOnly,
+		}
+		mounts[wb[i].Name] = vm
+		allMounts = append(allMounts, vm)
 
 		// Only add this volume if it hasn't already been added
 		if !addedVolumes.Has(vv.Name) {
@@ -119,5 +130,57 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 			addedVolumes.Insert(vv.Name)
 		}
 	}
+
+	if !declaresWorkspaceUsage(ts) {
+		// Initialize StepTemplate if it hasn't been already
+		if ts.StepTemplate == nil {
+			ts.StepTemplate = &corev1.Container{}
+		}
+		ts.StepTemplate.VolumeMounts = append(ts.StepTemplate.VolumeMounts, allMounts...)
+		return &ts, nil
+	}
+
+	// Copy the Steps and Sidecars so that the caller's TaskSpec isn't modified
+	ts.Steps = append([]v1beta1.Step(nil), ts.Steps...)
+	for i, s := range ts.Steps {
+		vms := allMounts
+		if len(s.Workspaces) > 0 {
+			vms = usedMounts(s.Workspaces, mounts)
+		}
+		ts.Steps[i].VolumeMounts = append(s.VolumeMounts[:len(s.VolumeMounts):len(s.VolumeMounts)], vms...)
+	}
+	ts.Sidecars = append([]v1beta1.Sidecar(nil), ts.Sidecars...)
+	for i, s := range ts.Sidecars {
+		vms := usedMounts(s.Workspaces, mounts)
+		ts.Sidecars[i].VolumeMounts = append(s.VolumeMounts[:len(s.VolumeMounts):len(s.VolumeMounts)], vms...)
+	}
 	return &ts, nil
 }
+
+// declaresWorkspaceUsage returns true if any Step or Sidecar in ts declares
+// which workspaces it uses.
+func declaresWorkspaceUsage(ts v1beta1.TaskSpec) bool {
+	for _, s := range ts.Steps {
+		if len(s.Workspaces) > 0 {
+			return true
+		}
+	}
+	for _, s := range ts.Sidecars {
+		if len(s.Workspaces) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// usedMounts returns the VolumeMounts for the workspaces in usages. Workspaces
+// that were not bound (e.g. optional workspaces) are skipped.
+func usedMounts(usages []v1beta1.WorkspaceUsage, mounts map[string]corev1.VolumeMount) []corev1.VolumeMount {
+	var vms []corev1.VolumeMount
+	for _, u := range usages {
+		if vm, ok := mounts[u.Name]; ok {
+			vms = append(vms, vm)
+		}
+	}
+	return vms
+}
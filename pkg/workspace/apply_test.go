@@ -183,6 +183,56 @@ func TestCreateVolumes(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "binding a single workspace with projected",
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name: "custom",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "foo"}},
+				}},
+			},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-twkr2",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "foo"}},
+						}},
+					},
+				},
+			},
+		},
+	}, {
+		name: "binding a single workspace with csi",
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name: "custom",
+			CSI:  &corev1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-mnq6l",
+				VolumeSource: corev1.VolumeSource{
+					CSI: &corev1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"},
+				},
+			},
+		},
+	}, {
+		name: "binding a single workspace with oci",
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name: "custom",
+			OCI:  &v1beta1.WorkspaceOCI{Repository: "gcr.io/my-project/workspaces", To: "run-build"},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-hvpvf",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			v := workspace.CreateVolumes(tc.workspaces)
@@ -509,6 +559,131 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "steps and sidecars declaring the workspaces they use",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "isolated"},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "source",
+				}},
+			}, {
+				Container: corev1.Container{Name: "everything"},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container: corev1.Container{Name: "signer"},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "keys",
+				}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "keys",
+				ReadOnly: true,
+			}},
+		},
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}, {
+			Name: "keys",
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "signing-keys",
+			},
+		}},
+		expectedTaskSpec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name: "isolated",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "ws-mnq6l",
+						MountPath: "/workspace/source",
+					}},
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "source",
+				}},
+			}, {
+				Container: corev1.Container{
+					Name: "everything",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "ws-mnq6l",
+						MountPath: "/workspace/source",
+					}, {
+						Name:      "ws-hvpvf",
+						MountPath: "/workspace/keys",
+						ReadOnly:  true,
+					}},
+				},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container: corev1.Container{
+					Name: "signer",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "ws-hvpvf",
+						MountPath: "/workspace/keys",
+						ReadOnly:  true,
+					}},
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{
+					Name: "keys",
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "ws-mnq6l",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}, {
+				Name: "ws-hvpvf",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "signing-keys",
+					},
+				},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "keys",
+				ReadOnly: true,
+			}},
+		},
+	}, {
+		name: "binding a workspace readOnly mounts it readOnly even if the task declares it writable",
+		ts: v1beta1.TaskSpec{
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}},
+		},
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			ReadOnly: true,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pvc",
+			},
+		}},
+		expectedTaskSpec: v1beta1.TaskSpec{
+			StepTemplate: &corev1.Container{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-mjxbm",
+					MountPath: "/workspace/source",
+					ReadOnly:  true,
+				}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "ws-mjxbm",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "pvc",
+					},
+				},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.workspaces)
@@ -0,0 +1,246 @@
+// +build e2e
+
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knativetest "knative.dev/pkg/test"
+)
+
+const (
+	s3SecretName            = "s3-secret"
+	s3SecretKey             = "credentials"
+	s3ResourceName          = "s3-files"
+	s3TestPipelineName      = "s3-test-pipeline"
+	s3TestPipelineRunName   = "s3-test-pipeline-run"
+	awsCLIImage             = "docker.io/amazon/aws-cli:2.1.10"
+	s3CredentialsFileFormat = "[default]\naws_access_key_id = %s\naws_secret_access_key = %s\n"
+)
+
+// TestS3StorageBucketPipelineRun is an integration test that will verify a pipeline
+// can use an S3-compatible bucket, e.g. a MinIO deployed in the cluster, for temporary
+// storage of artifacts shared between tasks and as a storage PipelineResource.
+func TestS3StorageBucketPipelineRun(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_ENDPOINT variable is not set.")
+	}
+	credentials := fmt.Sprintf(s3CredentialsFileFormat, os.Getenv("S3_ACCESS_KEY_ID"), os.Getenv("S3_SECRET_ACCESS_KEY"))
+	c, namespace := setup(ctx, t)
+	// Bucket tests can't run in parallel without causing issues with other tests.
+
+	knativetest.CleanupOnInterrupt(func() { tearDown(ctx, t, c, namespace) }, t.Logf)
+	defer tearDown(ctx, t, c, namespace)
+
+	bucketName := fmt.Sprintf("pipeline-test-%s-%d", namespace, time.Now().Unix())
+
+	t.Logf("Creating Secret %s", s3SecretName)
+	if _, err := c.KubeClient.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: s3SecretName, Namespace: namespace},
+		StringData: map[string]string{s3SecretKey: credentials},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Secret %q: %v", s3SecretName, err)
+	}
+
+	t.Logf("Creating S3 bucket %s", bucketName)
+	runAwsCLITask(ctx, c, t, namespace, "createbuckettask", fmt.Sprintf("aws --endpoint-url %s s3 mb s3://%s", endpoint, bucketName))
+	defer runAwsCLITask(ctx, c, t, namespace, "deletebuckettask", fmt.Sprintf("aws --endpoint-url %s s3 rb --force s3://%s", endpoint, bucketName))
+
+	originalConfigMap, err := c.KubeClient.CoreV1().ConfigMaps(systemNamespace).Get(ctx, config.GetArtifactBucketConfigName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get ConfigMap `%s`: %s", config.GetArtifactBucketConfigName(), err)
+	}
+	originalConfigMapData := originalConfigMap.Data
+
+	t.Logf("Creating ConfigMap %s", config.GetArtifactBucketConfigName())
+	configMapData := map[string]string{
+		config.BucketLocationKey:                 fmt.Sprintf("s3://%s", bucketName),
+		config.BucketServiceAccountSecretNameKey: s3SecretName,
+		config.BucketServiceAccountSecretKeyKey:  s3SecretKey,
+		config.BucketServiceAccountFieldNameKey:  "AWS_SHARED_CREDENTIALS_FILE",
+		config.BucketS3EndpointKey:               endpoint,
+		config.BucketS3ForcePathStyleKey:         "true",
+	}
+	if err := updateConfigMap(ctx, c.KubeClient, systemNamespace, config.GetArtifactBucketConfigName(), configMapData); err != nil {
+		t.Fatal(err)
+	}
+	defer resetConfigMap(ctx, t, c, systemNamespace, config.GetArtifactBucketConfigName(), originalConfigMapData)
+
+	t.Logf("Creating S3 PipelineResource %s", s3ResourceName)
+	s3Resource := tb.PipelineResource(s3ResourceName, tb.PipelineResourceSpec(
+		resourcev1alpha1.PipelineResourceTypeStorage,
+		tb.PipelineResourceSpecParam("type", resourcev1alpha1.PipelineResourceTypeS3),
+		tb.PipelineResourceSpecParam("location", fmt.Sprintf("s3://%s/files", bucketName)),
+		tb.PipelineResourceSpecParam("dir", "true"),
+		tb.PipelineResourceSpecParam("endpoint", endpoint),
+		tb.PipelineResourceSpecParam("forcePathStyle", "true"),
+		tb.PipelineResourceSpecSecretParam("AWS_SHARED_CREDENTIALS_FILE", s3SecretName, s3SecretKey),
+	))
+	if _, err := c.PipelineResourceClient.Create(ctx, s3Resource, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Pipeline Resource `%s`: %s", s3ResourceName, err)
+	}
+
+	resources := []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+		Name: "files", Type: resourcev1alpha1.PipelineResourceTypeStorage,
+	}}}
+	writeFileTask := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "write-file-task", Namespace: namespace},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "writefile", Image: "ubuntu"},
+				Script:    "echo hello > /workspace/output/files/newfile",
+			}},
+			Resources: &v1beta1.TaskResources{Outputs: resources},
+		},
+	}
+	readFileTask := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "read-file-task", Namespace: namespace},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "readfile", Image: "ubuntu"},
+				Script:    "grep hello /workspace/files/newfile",
+			}},
+			Resources: &v1beta1.TaskResources{Inputs: resources},
+		},
+	}
+	for _, task := range []*v1beta1.Task{writeFileTask, readFileTask} {
+		t.Logf("Creating Task %s", task.Name)
+		if _, err := c.TaskClient.Create(ctx, task, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create Task `%s`: %s", task.Name, err)
+		}
+	}
+
+	t.Logf("Creating Pipeline %s", s3TestPipelineName)
+	s3TestPipeline := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: s3TestPipelineName, Namespace: namespace},
+		Spec: v1beta1.PipelineSpec{
+			Resources: []v1beta1.PipelineDeclaredResource{{
+				Name: "files", Type: resourcev1alpha1.PipelineResourceTypeStorage,
+			}},
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "writefile",
+				TaskRef: &v1beta1.TaskRef{Name: writeFileTask.Name},
+				Resources: &v1beta1.PipelineTaskResources{
+					Outputs: []v1beta1.PipelineTaskOutputResource{{
+						Name: "files", Resource: "files",
+					}},
+				},
+			}, {
+				Name:    "readfile",
+				TaskRef: &v1beta1.TaskRef{Name: readFileTask.Name},
+				Resources: &v1beta1.PipelineTaskResources{
+					Inputs: []v1beta1.PipelineTaskInputResource{{
+						Name: "files", Resource: "files", From: []string{"writefile"},
+					}},
+				},
+			}},
+		},
+	}
+	if _, err := c.PipelineClient.Create(ctx, s3TestPipeline, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Pipeline `%s`: %s", s3TestPipelineName, err)
+	}
+
+	t.Logf("Creating PipelineRun %s", s3TestPipelineRunName)
+	s3TestPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: s3TestPipelineRunName, Namespace: namespace},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: s3TestPipelineName},
+			Resources: []v1beta1.PipelineResourceBinding{{
+				Name:        "files",
+				ResourceRef: &v1beta1.PipelineResourceRef{Name: s3ResourceName},
+			}},
+		},
+	}
+	if _, err := c.PipelineRunClient.Create(ctx, s3TestPipelineRun, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create PipelineRun `%s`: %s", s3TestPipelineRunName, err)
+	}
+
+	// Verify status of PipelineRun (wait for it)
+	if err := WaitForPipelineRunState(ctx, c, s3TestPipelineRunName, timeout, PipelineRunSucceed(s3TestPipelineRunName), "PipelineRunCompleted"); err != nil {
+		t.Errorf("Error waiting for PipelineRun %s to finish: %s", s3TestPipelineRunName, err)
+		t.Fatalf("PipelineRun execution failed")
+	}
+}
+
+// runAwsCLITask runs script in a TaskRun of the AWS CLI image, using the credentials of the S3 secret.
+func runAwsCLITask(ctx context.Context, c *clients, t *testing.T, namespace, name, script string) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "step1",
+					Image: awsCLIImage,
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "s3-secret-volume",
+						MountPath: fmt.Sprintf("/var/secret/%s", s3SecretName),
+					}},
+					Env: []corev1.EnvVar{{
+						Name: "AWS_SHARED_CREDENTIALS_FILE", Value: fmt.Sprintf("/var/secret/%s/%s", s3SecretName, s3SecretKey),
+					}},
+				},
+				Script: script,
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "s3-secret-volume",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: s3SecretName,
+					},
+				},
+			}},
+		},
+	}
+
+	t.Logf("Creating Task %s", name)
+	if _, err := c.TaskClient.Create(ctx, task, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Task `%s`: %s", name, err)
+	}
+
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "run", Namespace: namespace},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: name},
+		},
+	}
+
+	t.Logf("Creating TaskRun %s", taskRun.Name)
+	if _, err := c.TaskRunClient.Create(ctx, taskRun, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create TaskRun `%s`: %s", taskRun.Name, err)
+	}
+
+	if err := WaitForTaskRunState(ctx, c, taskRun.Name, TaskRunSucceed(taskRun.Name), "TaskRunSuccess"); err != nil {
+		t.Errorf("Error waiting for TaskRun %s to finish: %s", taskRun.Name, err)
+	}
+}
@@ -47,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamicclientset "k8s.io/client-go/dynamic/fake"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
@@ -57,6 +58,7 @@ import (
 	fakepodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 )
 
 // Data represents the desired state of the system (i.e. existing resources) to seed controllers
@@ -81,6 +83,7 @@ type Clients struct {
 	Pipeline    *fakepipelineclientset.Clientset
 	Resource    *fakeresourceclientset.Clientset
 	Kube        *fakekubeclientset.Clientset
+	Dynamic     *fakedynamicclientset.FakeDynamicClient
 	CloudEvents cloudeventclient.CEClient
 }
 
@@ -160,6 +163,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		Kube:        fakekubeclient.Get(ctx),
 		Pipeline:    fakepipelineclient.Get(ctx),
 		Resource:    fakeresourceclient.Get(ctx),
+		Dynamic:     fakedynamicclient.Get(ctx),
 		CloudEvents: cloudeventclient.Get(ctx),
 	}
 	// Every time a resource is modified, change the metadata.resourceVersion.
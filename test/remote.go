@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -37,6 +38,12 @@ import (
 // reference with a digest to fetch the image. Key must be specified as [lowercase kind]/[object name]. The image ref
 // with a digest is returned.
 func CreateImage(ref string, objs ...runtime.Object) (string, error) {
+	return CreateImageWithFiles(ref, nil, objs...)
+}
+
+// CreateImageWithFiles is like CreateImage, but also adds a layer for each of the files, keyed by their names, as the
+// files of a Tekton Bundle.
+func CreateImageWithFiles(ref string, files map[string]string, objs ...runtime.Object) (string, error) {
 	imgRef, err := name.ParseReference(ref)
 	if err != nil {
 		return "", fmt.Errorf("undexpected error producing image reference %w", err)
@@ -86,6 +93,29 @@ func CreateImage(ref string, objs ...runtime.Object) (string, error) {
 		}
 	}
 
+	fileNames := make([]string, 0, len(files))
+	for n := range files {
+		fileNames = append(fileNames, n)
+	}
+	sort.Strings(fileNames)
+	for _, n := range fileNames {
+		layer, err := tarball.LayerFromReader(strings.NewReader(files[n]))
+		if err != nil {
+			return "", fmt.Errorf("unexpected error adding layer to image %w", err)
+		}
+
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer: layer,
+			Annotations: map[string]string{
+				tkremote.TitleAnnotation: n,
+				tkremote.KindAnnotation:  tkremote.FileKind,
+			},
+		})
+		if err != nil {
+			return "", fmt.Errorf("could not add layer to image %w", err)
+		}
+	}
+
 	if err := remoteimg.Write(imgRef, img); err != nil {
 		return "", fmt.Errorf("could not push example image to registry")
 	}